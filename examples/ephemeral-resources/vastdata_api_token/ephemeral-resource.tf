@@ -0,0 +1,13 @@
+
+# The token is created for the duration of the run and revoked afterwards.
+# It is never written to the plan or state.
+ephemeral "vastdata_api_token" "ci_token" {
+  owner       = "ci-user"
+  expiry_date = "1h"
+}
+
+provider "vastdata" {
+  alias     = "ci"
+  host      = "vms.example.com"
+  api_token = ephemeral.vastdata_api_token.ci_token.token
+}
@@ -0,0 +1,14 @@
+
+# The S3 access key is created for the duration of the run and deleted afterwards.
+# It is never written to the plan or state.
+ephemeral "vastdata_user_key" "s3_key" {
+  username = "example-user"
+}
+
+provider "aws" {
+  access_key = ephemeral.vastdata_user_key.s3_key.access_key
+  secret_key = ephemeral.vastdata_user_key.s3_key.secret_key
+  endpoints {
+    s3 = "https://s3.example.com"
+  }
+}
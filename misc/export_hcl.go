@@ -0,0 +1,119 @@
+// Copyright (c) HashiCorp, Inc.
+
+//go:build ignore
+// +build ignore
+
+// Export existing cluster objects as Terraform configuration with import blocks.
+//
+// Connection settings are read from the same environment variables the provider uses:
+// VASTDATA_HOST, VASTDATA_PORT, VASTDATA_VERIFY_SSL, VASTDATA_CLUSTER_USERNAME,
+// VASTDATA_CLUSTER_PASSWORD and VASTDATA_API_TOKEN.
+//
+// Usage:
+//
+//	go run misc/export_hcl.go -out=./exported [-tenant=t1,t2] [-type=view,view_policy]
+package main
+
+import (
+	"context"
+	"flag"
+	"fmt"
+	"os"
+	"path/filepath"
+	"sort"
+	"strconv"
+	"strings"
+	"time"
+
+	vastdata "github.com/vast-data/terraform-provider-vastdata/vastdata"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+)
+
+var (
+	outDir  string
+	tenants string
+	kinds   string
+)
+
+func main() {
+	flag.StringVar(&outDir, "out", "exported", "Directory to write generated .tf files to")
+	flag.StringVar(&tenants, "tenant", "", "Optional comma-separated list of tenant names to export")
+	flag.StringVar(&kinds, "type", "", "Optional comma-separated list of resource types to export (e.g. view,vastdata_quota)")
+	flag.Parse()
+
+	host := os.Getenv("VASTDATA_HOST")
+	if host == "" {
+		fmt.Fprintln(os.Stderr, "VASTDATA_HOST must be set")
+		os.Exit(1)
+	}
+	port := int64(443)
+	if v := os.Getenv("VASTDATA_PORT"); v != "" {
+		parsed, err := strconv.ParseInt(v, 10, 64)
+		if err != nil {
+			fmt.Fprintf(os.Stderr, "invalid VASTDATA_PORT %q: %v\n", v, err)
+			os.Exit(1)
+		}
+		port = parsed
+	}
+	skipSSL := false
+	if v := os.Getenv("VASTDATA_VERIFY_SSL"); v != "" {
+		skipSSL = v == "1" || v == "true" || v == "TRUE"
+	}
+
+	rest, err := client.NewRest(
+		host,
+		port,
+		os.Getenv("VASTDATA_CLUSTER_USERNAME"),
+		os.Getenv("VASTDATA_CLUSTER_PASSWORD"),
+		os.Getenv("VASTDATA_API_TOKEN"),
+		!skipSSL,
+		"exporter",
+		time.Minute*4,
+	)
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create VAST client: %v\n", err)
+		os.Exit(1)
+	}
+
+	exporter := vastdata.NewExporter(rest, vastdata.ExportOptions{
+		Tenants: splitList(tenants),
+		Types:   splitList(kinds),
+	})
+	result, err := exporter.Export(context.Background())
+	if err != nil {
+		fmt.Fprintf(os.Stderr, "export failed: %v\n", err)
+		os.Exit(1)
+	}
+
+	if err := os.MkdirAll(outDir, 0o755); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to create %q: %v\n", outDir, err)
+		os.Exit(1)
+	}
+
+	names := make([]string, 0, len(result.Files))
+	for name := range result.Files {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	for _, name := range names {
+		target := filepath.Join(outDir, name)
+		if err := os.WriteFile(target, []byte(result.Files[name]), 0o644); err != nil {
+			fmt.Fprintf(os.Stderr, "failed to write %q: %v\n", target, err)
+			os.Exit(1)
+		}
+		fmt.Printf("✅ %s\n", target)
+	}
+	for _, w := range result.Warnings {
+		fmt.Fprintf(os.Stderr, "⚠️  %s\n", w)
+	}
+}
+
+func splitList(s string) []string {
+	var out []string
+	for _, item := range strings.Split(s, ",") {
+		if item = strings.TrimSpace(item); item != "" {
+			out = append(out, item)
+		}
+	}
+	return out
+}
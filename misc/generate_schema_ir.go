@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+
+//go:build ignore
+// +build ignore
+
+// Precomputes OpenAPI path items used by provider components (see vastdata/client/openapi_precomputed.go).
+// Run via "go generate ./vastdata/client/" (or "make generate-schema-ir") after updating embedded OpenAPI document.
+
+package main
+
+import (
+	"bytes"
+	"flag"
+	"fmt"
+	"os"
+	"sort"
+	"strings"
+
+	vastdata "github.com/vast-data/terraform-provider-vastdata/vastdata"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func main() {
+	var output string
+	flag.StringVar(&output, "output", client.PrecomputedSchemasFile(), "Output file (relative to vastdata/client)")
+	flag.Parse()
+
+	paths := componentPaths()
+	var buf bytes.Buffer
+	if err := client.BuildPrecomputedSchemas(&buf, paths); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to precompute schemas: %v\n", err)
+		os.Exit(1)
+	}
+	if err := os.WriteFile(output, buf.Bytes(), 0o644); err != nil {
+		fmt.Fprintf(os.Stderr, "failed to write %s: %v\n", output, err)
+		os.Exit(1)
+	}
+	fmt.Printf("Precomputed schemas of %d component paths (spec %s) into %s\n", len(paths), client.OpenAPISpecVersion, output)
+}
+
+// componentPaths returns OpenAPI paths schemas of resources and datasources are generated from:
+// create and read paths of SchemaRef and "<create path>/{id}" used to detect immutable fields.
+func componentPaths() []string {
+	seen := make(map[string]bool)
+	add := func(hints *is.TFStateHints) {
+		if hints == nil || hints.SchemaRef == nil {
+			return
+		}
+		for _, ref := range []*is.OpenAPIEndpointRef{hints.SchemaRef.Create, hints.SchemaRef.Read} {
+			if ref == nil || ref.Path == "" {
+				continue
+			}
+			seen[ref.Path] = true
+			seen[strings.Trim(ref.Path, "/")+"/{id}"] = true
+		}
+	}
+	for _, factory := range vastdata.GetResourceFactories() {
+		r, ok := factory().(*vastdata.Resource)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type: %T", factory()))
+		}
+		add(r.EmptyManager().TfState().Hints)
+	}
+	for _, factory := range vastdata.GetDatasourceFactories() {
+		d, ok := factory().(*vastdata.Datasource)
+		if !ok {
+			panic(fmt.Sprintf("unexpected type: %T", factory()))
+		}
+		add(d.EmptyManager().TfState().Hints)
+	}
+
+	paths := make([]string, 0, len(seen))
+	for path := range seen {
+		paths = append(paths, path)
+	}
+	sort.Strings(paths)
+	return paths
+}
@@ -26,6 +26,8 @@ func (m *ActiveDirectory) NewResourceManager(raw map[string]attr.Value, schema a
 		&is.TFStateHints{
 			SchemaRef:        ActiveDirectorySchemaRef,
 			SearchableFields: []string{"ldap_id", "domain_name", "machine_account_name"},
+			SensitiveFields:  []string{"bindpw"},
+			WriteOnlyFields:  []string{"bindpw"},
 		},
 	)}
 }
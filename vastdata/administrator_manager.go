@@ -25,6 +25,7 @@ func (m *AdministratorManager) NewResourceManager(raw map[string]attr.Value, sch
 		&is.TFStateHints{
 			SchemaRef:       AdministratorManagerSchemaRef,
 			SensitiveFields: []string{"password"},
+			VolatileFields:  []string{"failed_logins", "last_login"},
 		},
 	)}
 }
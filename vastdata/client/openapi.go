@@ -11,6 +11,7 @@ import (
 	"io"
 	"strings"
 	"sync"
+	"sync/atomic"
 
 	"github.com/getkin/kin-openapi/openapi3"
 )
@@ -24,7 +25,7 @@ var (
 )
 
 // loadOpenAPIDocOnce loads and parses the OpenAPI v3 document from a .tar.gz archive exactly once.
-// It looks for a file named "openapi-v3.json" inside the archive located at "api/openapi-v3.tar.gz".
+// It looks for a file named "api.json" inside the archive located at "api/<OpenAPISpecVersion>/api.tar.gz".
 // The document is parsed using the kin-openapi loader and cached for future calls.
 //
 // Returns:
@@ -36,53 +37,61 @@ var (
 //   - Errors encountered during the initial load are also cached and returned on subsequent calls.
 func loadOpenAPIDocOnce() (*openapi3.T, error) {
 	openApiDocOnce.Do(func() {
-		data, err := FS.ReadFile("api/5.3.0/api.tar.gz")
+		data, err := readEmbeddedSpec()
 		if err != nil {
-			openApiDocErr = fmt.Errorf("read embedded tar.gz: %w", err)
+			openApiDocErr = err
 			return
 		}
+		loader := openapi3.NewLoader()
+		openApiDoc, openApiDocErr = loader.LoadFromData(data)
+	})
 
-		gzr, err := gzip.NewReader(bytes.NewReader(data))
-		if err != nil {
-			openApiDocErr = fmt.Errorf("gzip reader: %w", err)
-			return
-		}
-		defer gzr.Close()
+	return openApiDoc, openApiDocErr
+}
 
-		tr := tar.NewReader(gzr)
+// readEmbeddedSpec returns raw api.json extracted from embedded archive of OpenAPISpecVersion.
+func readEmbeddedSpec() ([]byte, error) {
+	data, err := FS.ReadFile(specArchivePath())
+	if err != nil {
+		return nil, fmt.Errorf("read embedded tar.gz: %w", err)
+	}
 
-		for {
-			hdr, err := tr.Next()
-			if err == io.EOF {
-				openApiDocErr = fmt.Errorf("api.json not found in embedded archive")
-				return
-			}
-			if err != nil {
-				openApiDocErr = fmt.Errorf("tar read error: %w", err)
-				return
-			}
+	gzr, err := gzip.NewReader(bytes.NewReader(data))
+	if err != nil {
+		return nil, fmt.Errorf("gzip reader: %w", err)
+	}
+	defer gzr.Close()
 
-			if strings.HasSuffix(hdr.Name, "api.json") {
-				var buf bytes.Buffer
-				if _, err := io.Copy(&buf, tr); err != nil {
-					openApiDocErr = fmt.Errorf("copy api.json from tar: %w", err)
-					return
-				}
+	tr := tar.NewReader(gzr)
 
-				loader := openapi3.NewLoader()
-				openApiDoc, openApiDocErr = loader.LoadFromData(buf.Bytes())
-				return
-			}
+	for {
+		hdr, err := tr.Next()
+		if err == io.EOF {
+			return nil, fmt.Errorf("api.json not found in embedded archive")
+		}
+		if err != nil {
+			return nil, fmt.Errorf("tar read error: %w", err)
 		}
-	})
 
-	return openApiDoc, openApiDocErr
+		if strings.HasSuffix(hdr.Name, "api.json") {
+			var buf bytes.Buffer
+			if _, err := io.Copy(&buf, tr); err != nil {
+				return nil, fmt.Errorf("copy api.json from tar: %w", err)
+			}
+			return buf.Bytes(), nil
+		}
+	}
 }
 
 func GetOpenApiResource(resourcePath string) (*openapi3.PathItem, error) {
 
 	// Normalize path to ensure format like /users/
-	resourcePath = "/" + strings.Trim(resourcePath, "/") + "/"
+	resourcePath = normalizeResourcePath(resourcePath)
+
+	// Path items precomputed at build time do not require loading the whole document.
+	if resource, ok, err := precomputedPathItem(resourcePath); ok {
+		return resource, err
+	}
 
 	doc, err := loadOpenAPIDocOnce()
 	if err != nil {
@@ -96,17 +105,20 @@ func GetOpenApiResource(resourcePath string) (*openapi3.PathItem, error) {
 		for path := range doc.Paths.Map() {
 			available = append(available, path)
 		}
-
-		return nil, fmt.Errorf(
-			"path %q not found in OpenAPI schema. Available paths:\n  - %s",
-			resourcePath,
-			strings.Join(available, "\n  - "),
-		)
+		return nil, pathNotFoundError(resourcePath, available)
 	}
 
 	return resource, nil
 }
 
+func pathNotFoundError(resourcePath string, available []string) error {
+	return fmt.Errorf(
+		"path %q not found in OpenAPI schema. Available paths:\n  - %s",
+		resourcePath,
+		strings.Join(available, "\n  - "),
+	)
+}
+
 func GetOpenApiComponents() (*openapi3.Components, error) {
 	doc, err := loadOpenAPIDocOnce()
 
@@ -352,7 +364,34 @@ func isStringOrInteger(prop *openapi3.Schema) bool {
 	}
 }
 
+var (
+	resolvedSchemas             sync.Map // *openapi3.Schema -> resolved *openapi3.Schema
+	resolvedSchemaCacheDisabled atomic.Bool
+)
+
+// SetResolvedSchemaCache enables or disables memoization of ResolveComposedSchema (enabled by default).
+// Memoized schemas are dropped in both cases.
+func SetResolvedSchemaCache(enabled bool) {
+	resolvedSchemaCacheDisabled.Store(!enabled)
+	resolvedSchemas.Clear()
+}
+
+// ResolveComposedSchema merges allOf, converts polymorphic objects (see OneOf), picks the first typed
+// oneOf/anyOf alternative of primitives and sanitizes property names.
+// Schemas are never modified once loaded, so results are memoized per schema: the same schemas are resolved
+// for every resource, data source and import.
 func ResolveComposedSchema(schema *openapi3.Schema) *openapi3.Schema {
+	if schema == nil || resolvedSchemaCacheDisabled.Load() {
+		return resolveComposedSchema(schema)
+	}
+	if resolved, ok := resolvedSchemas.Load(schema); ok {
+		return resolved.(*openapi3.Schema)
+	}
+	resolved, _ := resolvedSchemas.LoadOrStore(schema, resolveComposedSchema(schema))
+	return resolved.(*openapi3.Schema)
+}
+
+func resolveComposedSchema(schema *openapi3.Schema) *openapi3.Schema {
 	if schema == nil {
 		return nil
 	}
@@ -390,20 +429,25 @@ func ResolveComposedSchema(schema *openapi3.Schema) *openapi3.Schema {
 				merged.Type = sub.Type
 			}
 		}
-		return merged
+		return withSanitizedPropertyNames(merged)
+	}
+
+	// Polymorphic objects become object with one nested property per variant (see OneOf).
+	if polymorphic := resolvePolymorphicObject(schema); polymorphic != nil {
+		return polymorphic
 	}
 
-	// If there is no composition to resolve, return as-is.
+	// If there is no composition to resolve, return as-is (up to property names).
 	if schema.Type != nil && len(*schema.Type) > 0 {
-		return schema
+		return withSanitizedPropertyNames(schema)
 	}
 
-	// Resolve oneOf or anyOf by picking the first resolvable schema with a type
+	// Resolve oneOf or anyOf of primitives by picking the first resolvable schema with a type
 	for _, refList := range [][]*openapi3.SchemaRef{schema.OneOf, schema.AnyOf} {
 		for _, subRef := range refList {
 			sub := ResolveAllRefs(subRef)
 			if sub != nil && sub.Type != nil && len(*sub.Type) > 0 {
-				return sub
+				return withSanitizedPropertyNames(sub)
 			}
 		}
 	}
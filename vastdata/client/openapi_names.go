@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"regexp"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// APINamesExtension is the schema extension key under which ResolveComposedSchema stores
+// mapping of sanitized property names to original API names (eg "start_at" -> "start-at").
+const APINamesExtension = "x-tf-api-names"
+
+var (
+	validPropertyName   = regexp.MustCompile(`^[a-z_][a-z0-9_]*$`)
+	snakeCaseLowerUpper = regexp.MustCompile(`([a-z0-9])([A-Z])`)
+	snakeCaseUpperWord  = regexp.MustCompile(`([A-Z]+)([A-Z][a-z])`)
+	snakeCaseInvalid    = regexp.MustCompile(`[^a-z0-9]+`)
+)
+
+// SanitizePropertyName converts API property name into valid Terraform attribute name.
+// Valid names are returned as is, others (eg "start-at", "keep.local") are converted to snake_case.
+func SanitizePropertyName(name string) string {
+	if name == "" || validPropertyName.MatchString(name) {
+		return name
+	}
+	sanitized := toSnakeCase(name)
+	if sanitized == "" || sanitized[0] >= '0' && sanitized[0] <= '9' {
+		sanitized = "_" + sanitized
+	}
+	return sanitized
+}
+
+// GetAPINames returns mapping of sanitized property names to original API names
+// attached by ResolveComposedSchema, or nil if all properties of schema have valid names.
+func GetAPINames(schema *openapi3.Schema) map[string]string {
+	if schema == nil || schema.Extensions == nil {
+		return nil
+	}
+	names, _ := schema.Extensions[APINamesExtension].(map[string]string)
+	return names
+}
+
+// withSanitizedPropertyNames returns copy of object schema with property names that are not valid
+// Terraform identifiers replaced by their snake_case form. The mapping is recorded under APINamesExtension.
+// Schemas without such properties are returned as is.
+func withSanitizedPropertyNames(schema *openapi3.Schema) *openapi3.Schema {
+	names := make(map[string]string)
+	for name := range schema.Properties {
+		if sanitized := SanitizePropertyName(name); sanitized != name {
+			if _, clash := schema.Properties[sanitized]; !clash {
+				names[sanitized] = name
+			}
+		}
+	}
+	if len(names) == 0 {
+		return schema
+	}
+
+	original := make(map[string]string, len(names))
+	for sanitized, name := range names {
+		original[name] = sanitized
+	}
+	rename := func(name string) string {
+		if sanitized, ok := original[name]; ok {
+			return sanitized
+		}
+		return name
+	}
+
+	cp := *schema
+	cp.Properties = make(openapi3.Schemas, len(schema.Properties))
+	for name, prop := range schema.Properties {
+		cp.Properties[rename(name)] = prop
+	}
+	cp.Required = make([]string, 0, len(schema.Required))
+	for _, name := range schema.Required {
+		cp.Required = append(cp.Required, rename(name))
+	}
+	cp.Extensions = make(map[string]any, len(schema.Extensions)+1)
+	for k, v := range schema.Extensions {
+		cp.Extensions[k] = v
+	}
+	cp.Extensions[APINamesExtension] = names
+	return &cp
+}
+
+// toSnakeCase converts arbitrary label (PascalCase, camelCase, kebab-case, ...) into snake_case identifier.
+func toSnakeCase(s string) string {
+	s = snakeCaseUpperWord.ReplaceAllString(s, "${1}_${2}")
+	s = snakeCaseLowerUpper.ReplaceAllString(s, "${1}_${2}")
+	s = snakeCaseInvalid.ReplaceAllString(strings.ToLower(s), "_")
+	return strings.Trim(s, "_")
+}
@@ -0,0 +1,42 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestSanitizePropertyName(t *testing.T) {
+	for name, expected := range map[string]string{
+		"keep_local":  "keep_local",
+		"start-at":    "start_at",
+		"keep.remote": "keep_remote",
+		"maxSize":     "max_size",
+		"2fa":         "_2fa",
+		"-":           "_",
+	} {
+		require.Equal(t, expected, SanitizePropertyName(name), name)
+	}
+}
+
+func TestResolveComposedSchema_SanitizedPropertyNames(t *testing.T) {
+	schema := objectSchema("Frame", "every", "start-at", "keep-local")
+	schema.Required = []string{"every", "start-at"}
+
+	resolved := ResolveComposedSchema(schema)
+	require.Contains(t, resolved.Properties, "start_at")
+	require.Contains(t, resolved.Properties, "keep_local")
+	require.NotContains(t, resolved.Properties, "start-at")
+	require.ElementsMatch(t, []string{"every", "start_at"}, resolved.Required)
+	require.Equal(t, map[string]string{"start_at": "start-at", "keep_local": "keep-local"}, GetAPINames(resolved))
+
+	// Original schema is left untouched.
+	require.Contains(t, schema.Properties, "start-at")
+	require.Nil(t, GetAPINames(schema))
+
+	// Schemas with valid names only are returned as is.
+	plain := objectSchema("Plain", "every")
+	require.Same(t, plain, ResolveComposedSchema(plain))
+}
@@ -0,0 +1,123 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"fmt"
+	"slices"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+// OneOfExtension is the schema extension key under which ResolveComposedSchema stores *OneOf
+// for polymorphic (oneOf/anyOf) objects.
+const OneOfExtension = "x-tf-one-of"
+
+// OneOf describes polymorphic object resolved from oneOf/anyOf object variants.
+// Every variant becomes optional nested property of resolved object, exactly one of them is expected to be set.
+// API on the other hand works with flat object of chosen variant (optionally tagged with Discriminator property).
+type OneOf struct {
+	// Discriminator is the name of API property which selects variant. Empty if spec defines no discriminator.
+	Discriminator string
+	// Variants maps variant property name to its discriminator value.
+	Variants map[string]string
+}
+
+// GetOneOf returns polymorphic metadata attached by ResolveComposedSchema or nil for regular schemas.
+func GetOneOf(schema *openapi3.Schema) *OneOf {
+	if schema == nil || schema.Extensions == nil {
+		return nil
+	}
+	oneOf, _ := schema.Extensions[OneOfExtension].(*OneOf)
+	return oneOf
+}
+
+// resolvePolymorphicObject converts oneOf/anyOf with at least two object variants into object schema
+// with one property per variant. Variant names come from discriminator mapping where available,
+// then from referenced component name, then from variant title.
+// Returns nil if schema is not polymorphic object (eg oneOf of primitives).
+func resolvePolymorphicObject(schema *openapi3.Schema) *openapi3.Schema {
+	refs := schema.OneOf
+	if len(refs) == 0 {
+		refs = schema.AnyOf
+	}
+	if len(refs) < 2 {
+		return nil
+	}
+
+	oneOf := &OneOf{Variants: make(map[string]string, len(refs))}
+	mapping := make(map[string]string) // component name -> discriminator value
+	if d := schema.Discriminator; d != nil {
+		oneOf.Discriminator = d.PropertyName
+		for value, ref := range d.Mapping {
+			mapping[componentName(ref)] = value
+		}
+	}
+
+	props := make(openapi3.Schemas, len(refs))
+	for i, subRef := range refs {
+		sub := subRef.Value
+		if sub == nil {
+			sub = ResolveAllRefs(subRef)
+		}
+		sub = ResolveComposedSchema(sub)
+		if sub == nil || sub.Type == nil || !sub.Type.Is(openapi3.TypeObject) || len(sub.Properties) == 0 {
+			return nil
+		}
+
+		component := componentName(schemaRefName(subRef))
+		value, ok := mapping[component]
+		if !ok && oneOf.Discriminator != "" {
+			// Implicit mapping: discriminator value is the component name.
+			value = component
+		}
+		label := value
+		if label == "" {
+			label = component
+		}
+		if label == "" {
+			label = sub.Title
+		}
+		name := toSnakeCase(label)
+		if _, exists := props[name]; name == "" || exists {
+			name = fmt.Sprintf("variant_%d", i+1)
+		}
+
+		oneOf.Variants[name] = value
+		props[name] = &openapi3.SchemaRef{Value: withoutProperty(sub, SanitizePropertyName(oneOf.Discriminator))}
+	}
+
+	return &openapi3.Schema{
+		Type:        &openapi3.Types{openapi3.TypeObject},
+		Title:       schema.Title,
+		Description: schema.Description,
+		Properties:  props,
+		Extensions:  map[string]any{OneOfExtension: oneOf},
+	}
+}
+
+// withoutProperty returns shallow copy of object schema without given property.
+// Used to hide discriminator property from variants since it is implied by chosen variant.
+func withoutProperty(schema *openapi3.Schema, name string) *openapi3.Schema {
+	if name == "" {
+		return schema
+	}
+	if _, ok := schema.Properties[name]; !ok {
+		return schema
+	}
+	cp := *schema
+	cp.Properties = make(openapi3.Schemas, len(schema.Properties)-1)
+	for k, v := range schema.Properties {
+		if k != name {
+			cp.Properties[k] = v
+		}
+	}
+	cp.Required = slices.DeleteFunc(slices.Clone(schema.Required), func(s string) bool { return s == name })
+	return &cp
+}
+
+// componentName returns last segment of reference ("#/components/schemas/PrefixFilter" -> "PrefixFilter").
+func componentName(ref string) string {
+	return ref[strings.LastIndex(ref, "/")+1:]
+}
@@ -0,0 +1,107 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/require"
+)
+
+func objectSchema(title string, props ...string) *openapi3.Schema {
+	s := &openapi3.Schema{
+		Type:       &openapi3.Types{openapi3.TypeObject},
+		Title:      title,
+		Properties: openapi3.Schemas{},
+	}
+	for _, p := range props {
+		s.Properties[p] = &openapi3.SchemaRef{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}}
+	}
+	return s
+}
+
+func TestResolveComposedSchema_OneOfDiscriminator(t *testing.T) {
+	prefix := objectSchema("", "type", "prefix")
+	prefix.Required = []string{"type", "prefix"}
+	tag := objectSchema("", "type", "key", "value")
+	schema := &openapi3.Schema{
+		Description: "Lifecycle rule filter",
+		OneOf: openapi3.SchemaRefs{
+			{Ref: "#/components/schemas/PrefixFilter", Value: prefix},
+			{Ref: "#/components/schemas/TagFilter", Value: tag},
+		},
+		Discriminator: &openapi3.Discriminator{
+			PropertyName: "type",
+			Mapping:      openapi3.StringMap{"byPrefix": "#/components/schemas/PrefixFilter"},
+		},
+	}
+
+	resolved := ResolveComposedSchema(schema)
+	require.True(t, resolved.Type.Is(openapi3.TypeObject))
+	require.Equal(t, "Lifecycle rule filter", resolved.Description)
+	require.ElementsMatch(t, []string{"by_prefix", "tag_filter"}, keys(resolved.Properties))
+
+	oneOf := GetOneOf(resolved)
+	require.NotNil(t, oneOf)
+	require.Equal(t, "type", oneOf.Discriminator)
+	// Explicit mapping for PrefixFilter, implicit (component name) for TagFilter.
+	require.Equal(t, map[string]string{"by_prefix": "byPrefix", "tag_filter": "TagFilter"}, oneOf.Variants)
+
+	// Discriminator is implied by variant and hidden from variant properties.
+	byPrefix := resolved.Properties["by_prefix"].Value
+	require.ElementsMatch(t, []string{"prefix"}, keys(byPrefix.Properties))
+	require.Equal(t, []string{"prefix"}, byPrefix.Required)
+	require.Contains(t, prefix.Properties, "type", "original variant schema must not be modified")
+
+	// Resolving already resolved schema is no-op.
+	require.Same(t, resolved, ResolveComposedSchema(resolved))
+}
+
+func TestResolveComposedSchema_AnyOfWithoutDiscriminator(t *testing.T) {
+	schema := &openapi3.Schema{
+		AnyOf: openapi3.SchemaRefs{
+			{Value: objectSchema("WebhookConfig", "url")},
+			{Value: objectSchema("", "topic")},
+		},
+	}
+	resolved := ResolveComposedSchema(schema)
+	oneOf := GetOneOf(resolved)
+	require.NotNil(t, oneOf)
+	require.Empty(t, oneOf.Discriminator)
+	require.Equal(t, map[string]string{"webhook_config": "", "variant_2": ""}, oneOf.Variants)
+}
+
+func TestResolveComposedSchema_OneOfPrimitives(t *testing.T) {
+	str := &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeString}}
+	schema := &openapi3.Schema{
+		OneOf: openapi3.SchemaRefs{
+			{Value: str},
+			{Value: &openapi3.Schema{Type: &openapi3.Types{openapi3.TypeInteger}}},
+		},
+	}
+	// Non object variants keep legacy behavior: first typed variant wins.
+	require.Same(t, str, ResolveComposedSchema(schema))
+	require.Nil(t, GetOneOf(str))
+}
+
+func TestToSnakeCase(t *testing.T) {
+	for in, want := range map[string]string{
+		"PrefixFilter":   "prefix_filter",
+		"byPrefix":       "by_prefix",
+		"HTTPServer":     "http_server",
+		"kafka-broker":   "kafka_broker",
+		"S3 Replication": "s3_replication",
+		"":               "",
+	} {
+		require.Equal(t, want, toSnakeCase(in), in)
+	}
+}
+
+func keys(m openapi3.Schemas) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,568 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Schemas of provider components are generated from path items of the embedded OpenAPI document.
+// Parsing the whole document (and resolving its $refs) is the most expensive part of provider startup,
+// so path items used by components are precomputed at build time ("go generate", see
+// misc/generate_schema_ir.go): only operation parts schemas are generated from are kept, all $refs are
+// inlined and every path item is encoded on its own to be decoded without the document.
+// Paths that are not precomputed, as well as stale precomputed schemas (built from another document),
+// fall back to the document.
+
+package client
+
+//go:generate go run ../../misc/generate_schema_ir.go
+
+import (
+	"bytes"
+	"compress/gzip"
+	"crypto/sha256"
+	"encoding/hex"
+	"encoding/json"
+	"fmt"
+	"io"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+	"sync"
+	"sync/atomic"
+
+	"github.com/getkin/kin-openapi/openapi3"
+)
+
+const (
+	// OpenAPISpecVersion is the version of embedded OpenAPI document.
+	OpenAPISpecVersion = "5.3.0"
+
+	// RefExtension is the schema extension key under which precomputed path items keep original $ref
+	// of inlined schemas (eg to name polymorphic variants after referenced components, see OneOf).
+	RefExtension = "x-tf-ref"
+)
+
+// precomputedSchemas is the content of precomputed schemas file.
+type precomputedSchemas struct {
+	// SpecVersion and SpecSHA256 identify the archive path items were resolved from.
+	SpecVersion string `json:"spec_version"`
+	SpecSHA256  string `json:"spec_sha256"`
+	// DocumentPaths lists all paths of the document, so lookups of missing paths fail without loading it.
+	DocumentPaths []string `json:"document_paths"`
+	// Paths maps normalized resource path (eg "/users/") to pathItemIR.
+	// Path items are decoded only when requested.
+	Paths map[string]json.RawMessage `json:"paths"`
+}
+
+// pathItemIR is resolved intermediate representation of OpenAPI path item: parts of GET, POST and PATCH
+// operations that schemas are generated from, with all $refs inlined.
+type pathItemIR struct {
+	Get   *operationIR `json:"get,omitempty"`
+	Post  *operationIR `json:"post,omitempty"`
+	Patch *operationIR `json:"patch,omitempty"`
+}
+
+type operationIR struct {
+	Parameters []*parameterIR `json:"parameters,omitempty"`
+	// RequestBody maps content type to request body schema.
+	RequestBody map[string]*schemaIR `json:"request_body,omitempty"`
+	// Responses maps status code to response schemas by content type.
+	Responses map[int]map[string]*schemaIR `json:"responses,omitempty"`
+}
+
+type parameterIR struct {
+	Name        string         `json:"name"`
+	In          string         `json:"in"`
+	Description string         `json:"description,omitempty"`
+	Required    bool           `json:"required,omitempty"`
+	Deprecated  bool           `json:"deprecated,omitempty"`
+	Extensions  map[string]any `json:"extensions,omitempty"`
+	Schema      *schemaIR      `json:"schema,omitempty"`
+}
+
+// schemaIR mirrors openapi3.Schema. Unlike openapi3.Schema it is decoded by encoding/json directly,
+// which is several times faster. Collections are not omitted to keep nil and empty values apart.
+type schemaIR struct {
+	// Ref is original $ref of inlined schema (see RefExtension).
+	Ref        string         `json:"ref,omitempty"`
+	Extensions map[string]any `json:"extensions"`
+
+	OneOf        []*schemaIR            `json:"one_of"`
+	AnyOf        []*schemaIR            `json:"any_of"`
+	AllOf        []*schemaIR            `json:"all_of"`
+	Not          *schemaIR              `json:"not,omitempty"`
+	Type         *[]string              `json:"type,omitempty"`
+	Title        string                 `json:"title,omitempty"`
+	Format       string                 `json:"format,omitempty"`
+	Description  string                 `json:"description,omitempty"`
+	Enum         []any                  `json:"enum"`
+	Default      any                    `json:"default,omitempty"`
+	Example      any                    `json:"example,omitempty"`
+	ExternalDocs *openapi3.ExternalDocs `json:"external_docs,omitempty"`
+
+	UniqueItems     bool          `json:"unique_items,omitempty"`
+	ExclusiveMin    bool          `json:"exclusive_min,omitempty"`
+	ExclusiveMax    bool          `json:"exclusive_max,omitempty"`
+	Nullable        bool          `json:"nullable,omitempty"`
+	ReadOnly        bool          `json:"read_only,omitempty"`
+	WriteOnly       bool          `json:"write_only,omitempty"`
+	AllowEmptyValue bool          `json:"allow_empty_value,omitempty"`
+	Deprecated      bool          `json:"deprecated,omitempty"`
+	XML             *openapi3.XML `json:"xml,omitempty"`
+
+	Min        *float64 `json:"min,omitempty"`
+	Max        *float64 `json:"max,omitempty"`
+	MultipleOf *float64 `json:"multiple_of,omitempty"`
+
+	MinLength uint64  `json:"min_length,omitempty"`
+	MaxLength *uint64 `json:"max_length,omitempty"`
+	Pattern   string  `json:"pattern,omitempty"`
+
+	MinItems uint64    `json:"min_items,omitempty"`
+	MaxItems *uint64   `json:"max_items,omitempty"`
+	Items    *schemaIR `json:"items,omitempty"`
+
+	Required                []string                `json:"required"`
+	Properties              map[string]*schemaIR    `json:"properties"`
+	MinProps                uint64                  `json:"min_props,omitempty"`
+	MaxProps                *uint64                 `json:"max_props,omitempty"`
+	AdditionalPropertiesHas *bool                   `json:"additional_properties_has,omitempty"`
+	AdditionalProperties    *schemaIR               `json:"additional_properties,omitempty"`
+	Discriminator           *openapi3.Discriminator `json:"discriminator,omitempty"`
+}
+
+var (
+	precomputedDisabled  atomic.Bool
+	precomputedOnce      sync.Once
+	precomputed          *precomputedSchemas
+	precomputedPathItems sync.Map // resource path -> *openapi3.PathItem
+)
+
+func specArchivePath() string {
+	return fmt.Sprintf("api/%s/api.tar.gz", OpenAPISpecVersion)
+}
+
+// PrecomputedSchemasFile returns path of precomputed schemas file relative to client package.
+func PrecomputedSchemasFile() string {
+	return fmt.Sprintf("api/%s/schema_ir.json.gz", OpenAPISpecVersion)
+}
+
+// SetPrecomputedSchemas enables or disables use of precomputed path items (enabled by default).
+// When disabled, all path items are resolved from the OpenAPI document.
+func SetPrecomputedSchemas(enabled bool) {
+	precomputedDisabled.Store(!enabled)
+}
+
+// HasPrecomputedSchema reports whether path item of the resource is precomputed and up to date.
+func HasPrecomputedSchema(resourcePath string) bool {
+	schemas := loadPrecomputedSchemasOnce()
+	if schemas == nil {
+		return false
+	}
+	_, ok := schemas.Paths[normalizeResourcePath(resourcePath)]
+	return ok
+}
+
+func normalizeResourcePath(resourcePath string) string {
+	return "/" + strings.Trim(resourcePath, "/") + "/"
+}
+
+// loadPrecomputedSchemasOnce reads precomputed schemas file exactly once.
+// Returns nil if the file is missing, malformed or was built from another OpenAPI document.
+func loadPrecomputedSchemasOnce() *precomputedSchemas {
+	precomputedOnce.Do(func() {
+		data, err := FS.ReadFile(PrecomputedSchemasFile())
+		if err != nil {
+			return
+		}
+		archive, err := FS.ReadFile(specArchivePath())
+		if err != nil {
+			return
+		}
+		gzr, err := gzip.NewReader(bytes.NewReader(data))
+		if err != nil {
+			return
+		}
+		defer gzr.Close()
+		var schemas precomputedSchemas
+		if err := json.NewDecoder(gzr).Decode(&schemas); err != nil {
+			return
+		}
+		if schemas.SpecVersion != OpenAPISpecVersion || schemas.SpecSHA256 != sha256Hex(archive) {
+			return
+		}
+		precomputed = &schemas
+	})
+	return precomputed
+}
+
+// precomputedPathItem returns precomputed path item of normalized resource path.
+// The second value is false if the path item has to be resolved from the document.
+// Paths missing in the document are reported with the same error as GetOpenApiResource.
+func precomputedPathItem(resourcePath string) (*openapi3.PathItem, bool, error) {
+	if precomputedDisabled.Load() {
+		return nil, false, nil
+	}
+	if item, ok := precomputedPathItems.Load(resourcePath); ok {
+		return item.(*openapi3.PathItem), true, nil
+	}
+	schemas := loadPrecomputedSchemasOnce()
+	if schemas == nil {
+		return nil, false, nil
+	}
+	raw, ok := schemas.Paths[resourcePath]
+	if !ok {
+		if !slices.Contains(schemas.DocumentPaths, resourcePath) {
+			return nil, true, pathNotFoundError(resourcePath, schemas.DocumentPaths)
+		}
+		return nil, false, nil
+	}
+	var ir pathItemIR
+	if err := json.Unmarshal(raw, &ir); err != nil {
+		return nil, false, nil
+	}
+	actual, _ := precomputedPathItems.LoadOrStore(resourcePath, ir.pathItem())
+	return actual.(*openapi3.PathItem), true, nil
+}
+
+// BuildPrecomputedSchemas resolves path items of given resource paths from embedded OpenAPI document
+// and writes them in precomputed schemas format (gzipped JSON). Paths missing in the document are skipped.
+func BuildPrecomputedSchemas(w io.Writer, resourcePaths []string) error {
+	archive, err := FS.ReadFile(specArchivePath())
+	if err != nil {
+		return fmt.Errorf("read embedded tar.gz: %w", err)
+	}
+	doc, err := loadOpenAPIDocOnce()
+	if err != nil {
+		return fmt.Errorf("failed to load OpenAPI document: %w", err)
+	}
+
+	schemas := precomputedSchemas{
+		SpecVersion: OpenAPISpecVersion,
+		SpecSHA256:  sha256Hex(archive),
+		Paths:       make(map[string]json.RawMessage, len(resourcePaths)),
+	}
+	for path := range doc.Paths.Map() {
+		schemas.DocumentPaths = append(schemas.DocumentPaths, path)
+	}
+	sort.Strings(schemas.DocumentPaths)
+	for _, resourcePath := range resourcePaths {
+		resourcePath = normalizeResourcePath(resourcePath)
+		item := doc.Paths.Map()[resourcePath]
+		if item == nil {
+			continue
+		}
+		ir, err := newPathItemIR(item)
+		if err != nil {
+			return fmt.Errorf("path %q: %w", resourcePath, err)
+		}
+		if schemas.Paths[resourcePath], err = json.Marshal(ir); err != nil {
+			return fmt.Errorf("path %q: %w", resourcePath, err)
+		}
+	}
+
+	gzw := gzip.NewWriter(w)
+	if err := json.NewEncoder(gzw).Encode(schemas); err != nil {
+		return err
+	}
+	return gzw.Close()
+}
+
+func newPathItemIR(item *openapi3.PathItem) (*pathItemIR, error) {
+	var (
+		ir  pathItemIR
+		err error
+	)
+	if ir.Get, err = newOperationIR(item.Get); err != nil {
+		return nil, fmt.Errorf("GET: %w", err)
+	}
+	if ir.Post, err = newOperationIR(item.Post); err != nil {
+		return nil, fmt.Errorf("POST: %w", err)
+	}
+	if ir.Patch, err = newOperationIR(item.Patch); err != nil {
+		return nil, fmt.Errorf("PATCH: %w", err)
+	}
+	return &ir, nil
+}
+
+func newOperationIR(op *openapi3.Operation) (*operationIR, error) {
+	if op == nil {
+		return nil, nil
+	}
+	ir := &operationIR{}
+	for _, ref := range op.Parameters {
+		if ref == nil || ref.Value == nil {
+			continue
+		}
+		p := ref.Value
+		schema, err := newSchemaIR(p.Schema, nil)
+		if err != nil {
+			return nil, fmt.Errorf("parameter %q: %w", p.Name, err)
+		}
+		ir.Parameters = append(ir.Parameters, &parameterIR{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Deprecated:  p.Deprecated,
+			Extensions:  p.Extensions,
+			Schema:      schema,
+		})
+	}
+	if op.RequestBody != nil && op.RequestBody.Value != nil {
+		schemas, err := newContentIR(op.RequestBody.Value.Content)
+		if err != nil {
+			return nil, fmt.Errorf("request body: %w", err)
+		}
+		ir.RequestBody = schemas
+	}
+	if op.Responses != nil {
+		for code, ref := range op.Responses.Map() {
+			status, err := strconv.Atoi(code)
+			if err != nil || ref == nil || ref.Value == nil {
+				continue // only responses with status codes are looked up
+			}
+			schemas, err := newContentIR(ref.Value.Content)
+			if err != nil {
+				return nil, fmt.Errorf("response %d: %w", status, err)
+			}
+			if ir.Responses == nil {
+				ir.Responses = make(map[int]map[string]*schemaIR)
+			}
+			ir.Responses[status] = schemas
+		}
+	}
+	return ir, nil
+}
+
+func newContentIR(content openapi3.Content) (map[string]*schemaIR, error) {
+	schemas := make(map[string]*schemaIR, len(content))
+	for contentType, mediaType := range content {
+		if mediaType == nil {
+			continue
+		}
+		schema, err := newSchemaIR(mediaType.Schema, nil)
+		if err != nil {
+			return nil, err
+		}
+		schemas[contentType] = schema
+	}
+	return schemas, nil
+}
+
+// newSchemaIR converts schema to intermediate representation with $refs replaced by referenced schemas,
+// so it can be resolved without the document. Cyclic references are reported as error.
+func newSchemaIR(ref *openapi3.SchemaRef, stack []*openapi3.Schema) (*schemaIR, error) {
+	if ref == nil {
+		return nil, nil
+	}
+	s := ref.Value
+	if s == nil {
+		s = ResolveAllRefs(ref)
+	}
+	if slices.Contains(stack, s) {
+		return nil, fmt.Errorf("cyclic reference %q", ref.Ref)
+	}
+	stack = append(stack, s)
+
+	ir := &schemaIR{
+		Ref:             ref.Ref,
+		Extensions:      s.Extensions,
+		Title:           s.Title,
+		Format:          s.Format,
+		Description:     s.Description,
+		Enum:            s.Enum,
+		Default:         s.Default,
+		Example:         s.Example,
+		ExternalDocs:    s.ExternalDocs,
+		UniqueItems:     s.UniqueItems,
+		ExclusiveMin:    s.ExclusiveMin,
+		ExclusiveMax:    s.ExclusiveMax,
+		Nullable:        s.Nullable,
+		ReadOnly:        s.ReadOnly,
+		WriteOnly:       s.WriteOnly,
+		AllowEmptyValue: s.AllowEmptyValue,
+		Deprecated:      s.Deprecated,
+		XML:             s.XML,
+		Min:             s.Min,
+		Max:             s.Max,
+		MultipleOf:      s.MultipleOf,
+		MinLength:       s.MinLength,
+		MaxLength:       s.MaxLength,
+		Pattern:         s.Pattern,
+		MinItems:        s.MinItems,
+		MaxItems:        s.MaxItems,
+		Required:        s.Required,
+		MinProps:        s.MinProps,
+		MaxProps:        s.MaxProps,
+		Discriminator:   s.Discriminator,
+
+		AdditionalPropertiesHas: s.AdditionalProperties.Has,
+	}
+	if s.Type != nil {
+		types := []string(*s.Type)
+		ir.Type = &types
+	}
+	var err error
+	for _, sub := range []struct {
+		ref  *openapi3.SchemaRef
+		dest **schemaIR
+	}{{s.Not, &ir.Not}, {s.Items, &ir.Items}, {s.AdditionalProperties.Schema, &ir.AdditionalProperties}} {
+		if *sub.dest, err = newSchemaIR(sub.ref, stack); err != nil {
+			return nil, err
+		}
+	}
+	for _, refs := range []struct {
+		refs openapi3.SchemaRefs
+		dest *[]*schemaIR
+	}{{s.OneOf, &ir.OneOf}, {s.AnyOf, &ir.AnyOf}, {s.AllOf, &ir.AllOf}} {
+		if refs.refs == nil {
+			continue
+		}
+		*refs.dest = make([]*schemaIR, len(refs.refs))
+		for i, sub := range refs.refs {
+			if (*refs.dest)[i], err = newSchemaIR(sub, stack); err != nil {
+				return nil, err
+			}
+		}
+	}
+	if s.Properties != nil {
+		ir.Properties = make(map[string]*schemaIR, len(s.Properties))
+		for name, prop := range s.Properties {
+			if ir.Properties[name], err = newSchemaIR(prop, stack); err != nil {
+				return nil, err
+			}
+		}
+	}
+	return ir, nil
+}
+
+// pathItem converts intermediate representation back to OpenAPI path item.
+func (ir *pathItemIR) pathItem() *openapi3.PathItem {
+	return &openapi3.PathItem{Get: ir.Get.operation(), Post: ir.Post.operation(), Patch: ir.Patch.operation()}
+}
+
+func (ir *operationIR) operation() *openapi3.Operation {
+	if ir == nil {
+		return nil
+	}
+	op := &openapi3.Operation{}
+	for _, p := range ir.Parameters {
+		op.Parameters = append(op.Parameters, &openapi3.ParameterRef{Value: &openapi3.Parameter{
+			Name:        p.Name,
+			In:          p.In,
+			Description: p.Description,
+			Required:    p.Required,
+			Deprecated:  p.Deprecated,
+			Extensions:  p.Extensions,
+			Schema:      p.Schema.schemaRef(),
+		}})
+	}
+	if ir.RequestBody != nil {
+		op.RequestBody = &openapi3.RequestBodyRef{Value: &openapi3.RequestBody{Content: content(ir.RequestBody)}}
+	}
+	op.Responses = openapi3.NewResponsesWithCapacity(len(ir.Responses))
+	for status, schemas := range ir.Responses {
+		op.Responses.Set(strconv.Itoa(status), &openapi3.ResponseRef{Value: &openapi3.Response{Content: content(schemas)}})
+	}
+	return op
+}
+
+func content(schemas map[string]*schemaIR) openapi3.Content {
+	c := make(openapi3.Content, len(schemas))
+	for contentType, schema := range schemas {
+		c[contentType] = &openapi3.MediaType{Schema: schema.schemaRef()}
+	}
+	return c
+}
+
+// schemaRef converts intermediate representation back to schema. Original $ref is kept under RefExtension,
+// while reference itself is left empty: the schema is already resolved.
+func (ir *schemaIR) schemaRef() *openapi3.SchemaRef {
+	if ir == nil {
+		return nil
+	}
+	s := &openapi3.Schema{
+		Extensions:      ir.Extensions,
+		Not:             ir.Not.schemaRef(),
+		Title:           ir.Title,
+		Format:          ir.Format,
+		Description:     ir.Description,
+		Enum:            ir.Enum,
+		Default:         ir.Default,
+		Example:         ir.Example,
+		ExternalDocs:    ir.ExternalDocs,
+		UniqueItems:     ir.UniqueItems,
+		ExclusiveMin:    ir.ExclusiveMin,
+		ExclusiveMax:    ir.ExclusiveMax,
+		Nullable:        ir.Nullable,
+		ReadOnly:        ir.ReadOnly,
+		WriteOnly:       ir.WriteOnly,
+		AllowEmptyValue: ir.AllowEmptyValue,
+		Deprecated:      ir.Deprecated,
+		XML:             ir.XML,
+		Min:             ir.Min,
+		Max:             ir.Max,
+		MultipleOf:      ir.MultipleOf,
+		MinLength:       ir.MinLength,
+		MaxLength:       ir.MaxLength,
+		Pattern:         ir.Pattern,
+		MinItems:        ir.MinItems,
+		MaxItems:        ir.MaxItems,
+		Items:           ir.Items.schemaRef(),
+		Required:        ir.Required,
+		MinProps:        ir.MinProps,
+		MaxProps:        ir.MaxProps,
+		Discriminator:   ir.Discriminator,
+		AdditionalProperties: openapi3.AdditionalProperties{
+			Has:    ir.AdditionalPropertiesHas,
+			Schema: ir.AdditionalProperties.schemaRef(),
+		},
+	}
+	if ir.Type != nil {
+		types := openapi3.Types(*ir.Type)
+		s.Type = &types
+	}
+	s.OneOf = schemaRefs(ir.OneOf)
+	s.AnyOf = schemaRefs(ir.AnyOf)
+	s.AllOf = schemaRefs(ir.AllOf)
+	if ir.Properties != nil {
+		s.Properties = make(openapi3.Schemas, len(ir.Properties))
+		for name, prop := range ir.Properties {
+			s.Properties[name] = prop.schemaRef()
+		}
+	}
+	if ir.Ref != "" {
+		s.Extensions = make(map[string]any, len(ir.Extensions)+1)
+		for k, v := range ir.Extensions {
+			s.Extensions[k] = v
+		}
+		s.Extensions[RefExtension] = ir.Ref
+	}
+	return &openapi3.SchemaRef{Value: s}
+}
+
+func schemaRefs(irs []*schemaIR) openapi3.SchemaRefs {
+	if irs == nil {
+		return nil
+	}
+	refs := make(openapi3.SchemaRefs, len(irs))
+	for i, ir := range irs {
+		refs[i] = ir.schemaRef()
+	}
+	return refs
+}
+
+// schemaRefName returns $ref of schema, including $ref of schemas inlined into precomputed path items.
+func schemaRefName(ref *openapi3.SchemaRef) string {
+	if ref == nil {
+		return ""
+	}
+	if ref.Ref != "" || ref.Value == nil {
+		return ref.Ref
+	}
+	name, _ := ref.Value.Extensions[RefExtension].(string)
+	return name
+}
+
+func sha256Hex(data []byte) string {
+	sum := sha256.Sum256(data)
+	return hex.EncodeToString(sum[:])
+}
@@ -0,0 +1,103 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"bytes"
+	"compress/gzip"
+	"encoding/json"
+	"sort"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestSchemaIR(t *testing.T) {
+	user := objectSchema("User", "name")
+	node := objectSchema("Node")
+	node.Properties["parent"] = &openapi3.SchemaRef{Ref: "#/components/schemas/Node", Value: node}
+	schema := &openapi3.Schema{
+		Type:  &openapi3.Types{openapi3.TypeArray},
+		Items: &openapi3.SchemaRef{Ref: "#/components/schemas/User", Value: user},
+	}
+
+	ir, err := newSchemaIR(&openapi3.SchemaRef{Value: schema}, nil)
+	require.NoError(t, err)
+	items := ir.schemaRef().Value.Items
+	assert.Empty(t, items.Ref)
+	assert.Equal(t, "#/components/schemas/User", schemaRefName(items))
+	assert.Contains(t, items.Value.Properties, "name")
+	// Referenced schema itself is not modified.
+	assert.Nil(t, user.Extensions)
+
+	_, err = newSchemaIR(&openapi3.SchemaRef{Ref: "#/components/schemas/Node", Value: node}, nil)
+	assert.ErrorContains(t, err, "cyclic reference")
+}
+
+func TestBuildPrecomputedSchemas(t *testing.T) {
+	var buf bytes.Buffer
+	require.NoError(t, BuildPrecomputedSchemas(&buf, []string{"users", "no/such/path"}))
+
+	gzr, err := gzip.NewReader(&buf)
+	require.NoError(t, err)
+	var schemas precomputedSchemas
+	require.NoError(t, json.NewDecoder(gzr).Decode(&schemas))
+	assert.Equal(t, OpenAPISpecVersion, schemas.SpecVersion)
+	assert.NotEmpty(t, schemas.SpecSHA256)
+	assert.Contains(t, schemas.DocumentPaths, "/users/")
+	require.Contains(t, schemas.Paths, "/users/")
+	assert.NotContains(t, schemas.Paths, "/no/such/path/")
+
+	var ir pathItemIR
+	require.NoError(t, json.Unmarshal(schemas.Paths["/users/"], &ir))
+	item := ir.pathItem()
+	require.NotNil(t, item.Get)
+	require.NotNil(t, item.Get.Responses.Status(200))
+	assert.NotEmpty(t, item.Get.Parameters)
+}
+
+func TestPrecomputedPathItem(t *testing.T) {
+	require.True(t, HasPrecomputedSchema("users"), `precomputed schemas are stale, run "go generate ./vastdata/client/"`)
+	item, ok, err := precomputedPathItem("/users/")
+	require.NoError(t, err)
+	require.True(t, ok)
+	require.NotNil(t, item.Get)
+	// Missing paths are reported without loading the document.
+	_, ok, err = precomputedPathItem("/no/such/path/")
+	assert.True(t, ok)
+	assert.ErrorContains(t, err, `path "/no/such/path/" not found in OpenAPI schema`)
+
+	// Precomputed path item provides the same schema as the document.
+	precomputedSchema, err := GetSchema_GET_StatusOk("users")
+	require.NoError(t, err)
+	SetPrecomputedSchemas(false)
+	defer SetPrecomputedSchemas(true)
+	_, ok, _ = precomputedPathItem("/users/")
+	assert.False(t, ok)
+	documentSchema, err := GetSchema_GET_StatusOk("users")
+	require.NoError(t, err)
+	assert.Equal(t, propertyNames(documentSchema.Value), propertyNames(precomputedSchema.Value))
+	for name, prop := range documentSchema.Value.Properties {
+		assert.Equal(t, prop.Value.Description, precomputedSchema.Value.Properties[name].Value.Description, name)
+	}
+}
+
+func TestSchemaRefName(t *testing.T) {
+	assert.Equal(t, "#/components/schemas/A", schemaRefName(&openapi3.SchemaRef{Ref: "#/components/schemas/A"}))
+	assert.Equal(t, "#/components/schemas/B", schemaRefName(&openapi3.SchemaRef{
+		Value: &openapi3.Schema{Extensions: map[string]any{RefExtension: "#/components/schemas/B"}},
+	}))
+	assert.Empty(t, schemaRefName(&openapi3.SchemaRef{Value: &openapi3.Schema{}}))
+	assert.Empty(t, schemaRefName(nil))
+}
+
+func propertyNames(schema *openapi3.Schema) []string {
+	var names []string
+	for name := range schema.Properties {
+		names = append(names, name)
+	}
+	sort.Strings(names)
+	return names
+}
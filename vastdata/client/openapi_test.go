@@ -0,0 +1,40 @@
+// Copyright (c) HashiCorp, Inc.
+
+package client
+
+import (
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestResolveComposedSchema_Memoized(t *testing.T) {
+	SetResolvedSchemaCache(true)
+	defer SetResolvedSchemaCache(true)
+
+	schema := &openapi3.Schema{
+		AllOf: openapi3.SchemaRefs{
+			{Value: objectSchema("Base", "start-at")},
+			{Value: objectSchema("Extra", "name")},
+		},
+	}
+	resolved := ResolveComposedSchema(schema)
+	require.NotNil(t, resolved)
+	assert.Contains(t, resolved.Properties, "start_at")
+	assert.Contains(t, resolved.Properties, "name")
+	assert.Same(t, resolved, ResolveComposedSchema(schema))
+
+	// Schemas resolved from the document are memoized as well.
+	ref, err := GetSchema_GET_StatusOk("users")
+	require.NoError(t, err)
+	again, err := GetSchema_GET_StatusOk("users")
+	require.NoError(t, err)
+	assert.Same(t, ref.Value, again.Value)
+
+	SetResolvedSchemaCache(false)
+	uncached := ResolveComposedSchema(schema)
+	assert.NotSame(t, resolved, uncached)
+	assert.Equal(t, resolved.Properties, uncached.Properties)
+}
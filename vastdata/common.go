@@ -15,15 +15,26 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
+	"errors"
 	"fmt"
 	"github.com/go-test/deep"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	vast_client "github.com/vast-data/go-vast-client"
 	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
 	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 	"github.com/vast-data/terraform-provider-vastdata/vastdata/schema_generation"
+	"maps"
 	"net/http"
+	"regexp"
+	"slices"
+	"strconv"
 	"strings"
+	"time"
 )
 
 var notImportable = false
@@ -50,6 +61,172 @@ var (
 	isApiError        = vast_client.IsApiError
 )
 
+// ApiErrorCategory is a coarse classification of VMS API errors used to suggest remediation.
+type ApiErrorCategory string
+
+const (
+	ApiErrorUnknown    ApiErrorCategory = ""
+	ApiErrorAuth       ApiErrorCategory = "auth"
+	ApiErrorPermission ApiErrorCategory = "permission"
+	ApiErrorLicense    ApiErrorCategory = "license"
+	ApiErrorConflict   ApiErrorCategory = "conflict"
+	ApiErrorValidation ApiErrorCategory = "validation"
+	ApiErrorServer     ApiErrorCategory = "server"
+)
+
+// apiErrorRule maps status codes and/or message pattern of ApiError to category and remediation.
+// Empty statusCodes matches any status code, nil pattern matches any message.
+type apiErrorRule struct {
+	category    ApiErrorCategory
+	statusCodes []int
+	pattern     *regexp.Regexp
+	remediation func(body string) string
+}
+
+var (
+	realmPattern      = regexp.MustCompile(`(?i)realm[\s:]+['"]?([\w-]+)['"]?`)
+	permissionPattern = regexp.MustCompile(`(?i)['"]?\b(create|view|edit|delete)\b['"]?\s+permission|permission\s+['"]?\b(create|view|edit|delete)\b`)
+)
+
+// apiErrorRules are evaluated in order, first match wins.
+// Message patterns go first as VMS does not always use specific status codes (e.g. license errors come as 400).
+var apiErrorRules = []apiErrorRule{
+	{
+		category: ApiErrorLicense,
+		statusCodes: []int{
+			http.StatusBadRequest, http.StatusPaymentRequired, http.StatusForbidden,
+			http.StatusConflict, http.StatusUnprocessableEntity,
+		},
+		// Only phrases about the license itself: fields like license_key in validation errors must not match.
+		pattern: regexp.MustCompile(`(?i)not (supported|allowed|enabled|included|permitted) by (the )?(current |cluster |installed )?licen[cs]e` +
+			`|licen[cs]e (has |is )?expired|licen[cs]e[\s\w]*(limit|capacity)[\s\w]*exceeded` +
+			`|(no|not|without) (a )?(valid )?licen[cs]e\b|\bnot licen[cs]ed|\bunlicen[cs]ed`),
+		remediation: func(string) string {
+			return "The feature is not enabled by the cluster license or the license capacity is exceeded. " +
+				"Check the license in VMS (Settings → License) or contact VAST support."
+		},
+	},
+	{
+		category: ApiErrorValidation,
+		pattern:  regexp.MustCompile(`(?i)(does not belong to|different|another|mismatch\w*)[\s\w]*tenant|tenant[\s\w]*(mismatch|does not match)`),
+		remediation: func(string) string {
+			return "Referenced objects belong to a different tenant. " +
+				"Make sure tenant_id of the resource matches the tenant of all referenced objects (policies, vip pools, users)."
+		},
+	},
+	{
+		category: ApiErrorConflict,
+		pattern:  regexp.MustCompile(`(?i)\bin use\b|is used by|being used|has dependent|referenced by|still attached`),
+		remediation: func(string) string {
+			return "The object is referenced by other objects. " +
+				"Remove or detach dependent objects first (e.g. views using the policy), " +
+				"or add depends_on so Terraform destroys dependents before this resource."
+		},
+	},
+	{
+		category:    ApiErrorAuth,
+		statusCodes: []int{http.StatusUnauthorized},
+		remediation: authRemediation,
+	},
+	{
+		category:    ApiErrorAuth,
+		pattern:     regexp.MustCompile(`(?i)authentication credentials|token is (invalid|expired)|invalid (username|password|token)|not authenticated`),
+		remediation: authRemediation,
+	},
+	{
+		category:    ApiErrorPermission,
+		statusCodes: []int{http.StatusForbidden},
+		remediation: permissionRemediation,
+	},
+	{
+		category:    ApiErrorPermission,
+		pattern:     regexp.MustCompile(`(?i)(do not|does not|doesn't|don't) have (the )?permission|permission denied|not permitted|insufficient permission`),
+		remediation: permissionRemediation,
+	},
+	{
+		category:    ApiErrorConflict,
+		statusCodes: []int{http.StatusConflict},
+		remediation: func(string) string {
+			return "The request conflicts with the current state of the object in VMS (it already exists or is in use). " +
+				"Import the existing object or remove conflicting objects first."
+		},
+	},
+	{
+		category:    ApiErrorValidation,
+		statusCodes: []int{http.StatusBadRequest, http.StatusUnprocessableEntity},
+		remediation: func(string) string {
+			return "VMS rejected the request. Check the values of the reported attributes " +
+				"and that the attributes are supported by the VMS version of the cluster."
+		},
+	},
+	{
+		category:    ApiErrorServer,
+		statusCodes: []int{http.StatusInternalServerError, http.StatusBadGateway, http.StatusServiceUnavailable, http.StatusGatewayTimeout},
+		remediation: func(string) string {
+			return "VMS failed to process the request. Retry later; " +
+				"if the problem persists, collect VMS logs and contact VAST support."
+		},
+	},
+}
+
+func authRemediation(string) string {
+	return "VMS did not accept provider credentials. " +
+		"Check username/password or api_token of the provider and make sure the token is neither expired nor revoked."
+}
+
+func permissionRemediation(body string) string {
+	var (
+		realm      string
+		permission string
+	)
+	if m := realmPattern.FindStringSubmatch(body); m != nil {
+		realm = m[1]
+	}
+	if m := permissionPattern.FindStringSubmatch(body); m != nil {
+		permission = strings.ToLower(m[1] + m[2])
+	}
+	switch {
+	case realm != "" && permission != "":
+		return fmt.Sprintf("The manager used by the provider lacks %q permission in the %q realm. "+
+			"Grant it via a role assigned to the manager (vastdata_administrator_role).", permission, realm)
+	case realm != "":
+		return fmt.Sprintf("The manager used by the provider lacks permissions in the %q realm. "+
+			"Grant them via a role assigned to the manager (vastdata_administrator_role).", realm)
+	default:
+		return "The manager used by the provider lacks permissions for this operation. " +
+			"Check roles and realms assigned to the manager (vastdata_administrator_role) " +
+			"and that the tenant of the manager matches tenant of the resource."
+	}
+}
+
+// classifyApiError maps ApiError to category and remediation text.
+// Returns ApiErrorUnknown for errors other than ApiError and for unrecognized errors (e.g. 404).
+func classifyApiError(err error) (ApiErrorCategory, string) {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		return ApiErrorUnknown, ""
+	}
+	for _, rule := range apiErrorRules {
+		if len(rule.statusCodes) > 0 && !slices.Contains(rule.statusCodes, apiErr.StatusCode) {
+			continue
+		}
+		if rule.pattern != nil && !rule.pattern.MatchString(apiErr.Body) {
+			continue
+		}
+		return rule.category, rule.remediation(apiErr.Body)
+	}
+	return ApiErrorUnknown, ""
+}
+
+// withRemediation appends remediation text for classified ApiError to diagnostic detail.
+func withRemediation(detail string, err error) string {
+	category, remediation := classifyApiError(err)
+	if category == ApiErrorUnknown {
+		return detail
+	}
+	return fmt.Sprintf("%s\n\n[%s] %s", detail, category, remediation)
+}
+
 type ForceCleanState struct{}
 
 func (ForceCleanState) Error() string {
@@ -73,6 +250,13 @@ const (
 	ValidatorRetentionFormat         = schema_generation.ValidatorRetentionFormat
 )
 
+// Common custom types
+const (
+	CustomTypeDuration    = schema_generation.CustomTypeDuration
+	CustomTypePath        = schema_generation.CustomTypePath
+	CustomTypeIPRangeList = schema_generation.CustomTypeIPRangeList
+)
+
 // MISC
 func withContext(ctx context.Context, method string, managerName string, fn func(ctx context.Context)) {
 	ctx = client.ContextWithRequestID(ctx)
@@ -95,87 +279,6 @@ func safeDeepEqual(expected, actual any) (diff []string, panicked bool) {
 // Common Resource Validators
 // ----------------------------------
 
-// validateOneOf ensures that at most one of the given fields is set (i.e., known and not null).
-// Returns an error if more than one field is set.
-func validateOneOf(tf *is.TFState, fields ...string) error {
-	var setFields []string
-
-	for _, field := range fields {
-		if tf.IsKnownAndNotNull(field) {
-			setFields = append(setFields, field)
-		}
-	}
-
-	if len(setFields) > 1 {
-		return fmt.Errorf("only one of %q can be set, but multiple were provided: %v", fields, setFields)
-	}
-
-	return nil
-}
-
-// validateAllOf ensures that all the given fields are set (i.e., known and not null).
-// Returns an error listing the fields that are missing.
-func validateAllOf(tf *is.TFState, fields ...string) error {
-	var missing []string
-
-	for _, field := range fields {
-		if !tf.IsKnownAndNotNull(field) {
-			missing = append(missing, field)
-		}
-	}
-
-	if len(missing) > 0 {
-		return fmt.Errorf("all of %q must be set, but missing: %v", fields, missing)
-	}
-
-	return nil
-}
-
-// validateNoneOf ensures that none of the given fields are set (i.e., all are null or unknown).
-// Returns an error listing the fields that were incorrectly set.
-func validateNoneOf(tf *is.TFState, fields ...string) error {
-	var setFields []string
-
-	for _, field := range fields {
-		if tf.IsKnownAndNotNull(field) {
-			setFields = append(setFields, field)
-		}
-	}
-
-	if len(setFields) > 0 {
-		return fmt.Errorf("none of %q should be set, but found: %v", fields, setFields)
-	}
-
-	return nil
-}
-
-// ValidateFieldIsOneOf ensures that the specified field in allows only a limited set of valid values.
-func ValidateFieldIsOneOf[T string | int64 | float64](tfState *is.TFState, field string, validValues ...T) error {
-	if !tfState.IsKnownAndNotNull(field) {
-		return nil // Field is not set, no validation needed
-	}
-
-	var value T
-	switch any(value).(type) {
-	case string:
-		value = any(tfState.String(field)).(T)
-	case int64:
-		value = any(tfState.Int64(field)).(T)
-	case float64:
-		value = any(tfState.Float64(field)).(T)
-	default:
-		return fmt.Errorf("unsupported type for validation: %T", value)
-	}
-
-	for _, validValue := range validValues {
-		if value == validValue {
-			return nil // Valid value found
-		}
-	}
-
-	return fmt.Errorf("field %q must be one of %v, but got '%v'", field, validValues, value)
-}
-
 // ValidateFieldIsNoneOf ensures that the specified field in allows none of the invalid values.
 func ValidateFieldIsNoneOf[T string | int64 | float64](tfState *is.TFState, field string, invalidValues ...T) error {
 	if !tfState.IsKnownAndNotNull(field) {
@@ -321,9 +424,12 @@ func getRecordBySearchParams(ctx context.Context, api VastResourceAPIWithContext
 
 // deleteRecordBySearchParams attempts to delete a resource by ID (preferred) or by generic search parameters.
 // It also supports appending additional deletion parameters using TFState hints (DeleteOnlyFields).
+// If DeleteRetryOnConflict hint is set, deletion blocked by dependent objects is retried with backoff
+// and objects still referencing the resource are listed in the returned error.
 //
 // Parameters:
 //   - ctx: request context
+//   - rest: REST client used to look up dependent objects
 //   - api: resource client interface
 //   - tfState: Terraform state wrapper
 //   - managerName: name of resource manager for logging
@@ -331,7 +437,7 @@ func getRecordBySearchParams(ctx context.Context, api VastResourceAPIWithContext
 //
 // Returns:
 //   - error: any error that occurred during deletion, excluding 404s (they are ignored)
-func deleteRecordBySearchParams(ctx context.Context, api VastResourceAPIWithContext, tfState *is.TFState, managerName, op string) error {
+func deleteRecordBySearchParams(ctx context.Context, rest *VMSRest, api VastResourceAPIWithContext, tfState *is.TFState, managerName, op string) error {
 	var err error
 	searchParams := getSearchParams(ctx, tfState, nil)
 	if len(searchParams) == 0 {
@@ -353,18 +459,130 @@ func deleteRecordBySearchParams(ctx context.Context, api VastResourceAPIWithCont
 		deleteQueryParams = tfState.GetDeleteOnlyQueryParams()
 	}
 
-	if id, ok := searchParams["id"]; ok {
-		tflog.Debug(ctx, fmt.Sprintf("%s[%s]: found ID = %v.", op, managerName, id))
-		// If the ID is set, we assume it's a direct call by ID
-		_, err = api.DeleteByIdWithContext(ctx, id, deleteQueryParams, deleteBodyParams)
-	} else {
+	id, idExists := searchParams["id"]
+	deleteFn := func() error {
+		if idExists {
+			tflog.Debug(ctx, fmt.Sprintf("%s[%s]: found ID = %v.", op, managerName, id))
+			// If the ID is set, we assume it's a direct call by ID
+			_, err := api.DeleteByIdWithContext(ctx, id, deleteQueryParams, deleteBodyParams)
+			return err
+		}
 		tflog.Debug(ctx, fmt.Sprintf("%s[%s]: no ID found, using search params.", op, managerName))
-		_, err = api.DeleteWithContext(ctx, searchParams, deleteQueryParams, deleteBodyParams)
+		_, err := api.DeleteWithContext(ctx, searchParams, deleteQueryParams, deleteBodyParams)
+		return err
+	}
+
+	retry := tfState.Hints.DeleteRetryOnConflict
+	if retry == nil {
+		return ignoreStatusCodes(deleteFn(), http.StatusNotFound)
+	}
+	timeout, err := tfState.DeleteTimeout()
+	if err != nil {
+		return err
+	}
+	if timeout == 0 {
+		timeout = retry.DefaultTimeout()
+	}
+	err = retryOnDependencyConflict(ctx, timeout, managerName, op, deleteFn)
+	if isDependencyConflict(err) && idExists && len(retry.Dependents) > 0 {
+		if dependents := findDependents(ctx, componentLister(rest), retry.Dependents, id); len(dependents) > 0 {
+			err = fmt.Errorf("%w\n\n%q is still referenced by: %s", err, managerName, strings.Join(dependents, ", "))
+		}
 	}
 	return ignoreStatusCodes(err, http.StatusNotFound)
 
 }
 
+// Delete retries on dependency conflicts. Variables to allow shorter intervals in tests.
+var (
+	deleteConflictInitialBackoff = 2 * time.Second
+	deleteConflictMaxBackoff     = 30 * time.Second
+)
+
+// isDependencyConflict reports whether err is VMS refusal to modify an object that is still in use.
+func isDependencyConflict(err error) bool {
+	category, _ := classifyApiError(err)
+	return category == ApiErrorConflict
+}
+
+// retryOnDependencyConflict calls fn until it returns anything but a dependency conflict
+// or timeout (the configured delete timeout) elapses. Backoff doubles up to deleteConflictMaxBackoff.
+// Returns the last error returned by fn.
+func retryOnDependencyConflict(ctx context.Context, timeout time.Duration, managerName, op string, fn func() error) error {
+	deadline := time.Now().Add(timeout)
+	if ctxDeadline, ok := ctx.Deadline(); ok && ctxDeadline.Before(deadline) {
+		deadline = ctxDeadline
+	}
+
+	backoff := deleteConflictInitialBackoff
+	err := fn()
+	for isDependencyConflict(err) {
+		if time.Now().Add(backoff).After(deadline) {
+			return err
+		}
+		tflog.Debug(ctx, fmt.Sprintf("%s[%s]: object is in use, retrying in %s: %s", op, managerName, backoff, err))
+		select {
+		case <-ctx.Done():
+			return err
+		case <-time.After(backoff):
+		}
+		backoff = min(backoff*2, deleteConflictMaxBackoff)
+		err = fn()
+	}
+	return err
+}
+
+// dependentLister lists records of the component with the given (snake case) name.
+type dependentLister func(ctx context.Context, component string, query params) (RecordSet, error)
+
+// componentLister returns dependentLister backed by APIs of registered components.
+func componentLister(rest *VMSRest) dependentLister {
+	return func(ctx context.Context, component string, query params) (RecordSet, error) {
+		for _, c := range allTFComponents {
+			if is.SnakeCaseName(c) == component {
+				return c.API(rest).ListWithContext(ctx, query)
+			}
+		}
+		return nil, fmt.Errorf("unknown component %q", component)
+	}
+}
+
+// findDependents returns human-readable descriptions of objects referencing the given id.
+// Lookup failures are logged and skipped: the result is informational only.
+func findDependents(ctx context.Context, list dependentLister, refs []is.DependentReference, id any) []string {
+	var dependents []string
+	for _, ref := range refs {
+		records, err := list(ctx, ref.Component, params{ref.Field: id})
+		if err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("failed to look up %s objects referencing id %v: %s", ref.Component, id, err))
+			continue
+		}
+		for _, record := range records {
+			// Do not rely on server side filtering: list fields (e.g. *_ids) may not support it.
+			if !referencesID(record[ref.Field], id) {
+				continue
+			}
+			label := fmt.Sprint(record["id"])
+			for _, k := range []string{"name", "path"} {
+				if v, ok := record[k].(string); ok && v != "" {
+					label = v
+					break
+				}
+			}
+			dependents = append(dependents, fmt.Sprintf("%s %q (id=%v, %s=%v)", ref.Component, label, record["id"], ref.Field, id))
+		}
+	}
+	return dependents
+}
+
+// referencesID reports whether value (scalar or list of ids) contains id.
+func referencesID(value, id any) bool {
+	if list, ok := value.([]any); ok {
+		return slices.ContainsFunc(list, func(v any) bool { return referencesID(v, id) })
+	}
+	return value != nil && fmt.Sprint(normalizeNumber(value)) == fmt.Sprint(normalizeNumber(id))
+}
+
 // ----------------------------------
 // Transformations
 // ----------------------------------
@@ -393,35 +611,184 @@ func normalizeNumber(v any) any {
 	return v
 }
 
-// convertMapKeysRecursive recursively applies a key transformation function to all
-// keys in a map[string]any and its nested structures. It also traverses lists containing maps.
-// This is useful for converting between naming conventions like snake_case and dash-case.
-func convertMapKeysRecursive(val any, convertFn func(string) string) any {
-	switch v := val.(type) {
+// ----------------------------------
+// API Error Diagnostics
+// ----------------------------------
+
+// vmsErrorGeneralKeys are keys of VMS error payload that are not bound to a particular field.
+var vmsErrorGeneralKeys = []string{"detail", "non_field_errors", "message", "error"}
+
+// addApiErrorDiagnostics reports err to diagnostics.
+// VMS rejects invalid requests with payload like {"field": ["message", ...], "detail": "..."}.
+// Messages for fields known to the schema are reported as attribute errors on the matching
+// (possibly nested) attribute path, so Terraform points at the offending configuration line.
+// Everything else (detail, unknown fields) is reported as a general error with the given summary.
+// If nothing can be bound to an attribute the error is reported as is.
+// Remediation text for errors recognized by classifyApiError is appended to the general error,
+// or reported as separate general error if all messages were bound to attributes.
+func addApiErrorDiagnostics(diags *diag.Diagnostics, summary string, err error, typeMap map[string]attr.Type) {
+	payload := parseApiErrorPayload(err)
+	if payload == nil {
+		diags.AddError(summary, withRemediation(err.Error(), err))
+		return
+	}
+
+	var (
+		general         []string
+		attributeErrors int
+	)
+	for _, k := range slices.Sorted(maps.Keys(payload)) {
+		v := payload[k]
+		if slices.Contains(vmsErrorGeneralKeys, k) {
+			general = append(general, apiErrorMessages(v)...)
+			continue
+		}
+		t, ok := typeMap[k]
+		if !ok {
+			general = append(general, fmt.Sprintf("%s: %s", k, strings.Join(apiErrorMessages(v), "; ")))
+			continue
+		}
+		attributeErrors += addApiAttributeErrors(diags, summary, path.Root(k), t, v)
+	}
+
+	if attributeErrors == 0 {
+		diags.AddError(summary, withRemediation(err.Error(), err))
+		return
+	}
+	if len(general) > 0 {
+		diags.AddError(summary, withRemediation(strings.Join(general, "\n"), err))
+		return
+	}
+	if category, remediation := classifyApiError(err); category != ApiErrorUnknown {
+		diags.AddError(summary, fmt.Sprintf("[%s] %s", category, remediation))
+	}
+}
+
+// parseApiErrorPayload returns decoded JSON object from the body of ApiError.
+// Returns nil if err is not an ApiError or body is not a JSON object.
+func parseApiErrorPayload(err error) map[string]any {
+	var apiErr *ApiError
+	if !errors.As(err, &apiErr) {
+		return nil
+	}
+	var payload map[string]any
+	if json.Unmarshal([]byte(apiErr.Body), &payload) != nil || len(payload) == 0 {
+		return nil
+	}
+	return payload
+}
+
+// addApiAttributeErrors adds attribute errors for value v of VMS error payload at path p.
+// Nested objects, maps and lists are followed as long as the attribute type allows it.
+// API property names of renamed objects and flat objects of polymorphic attributes are
+// translated back to Terraform paths.
+// Returns number of added diagnostics.
+func addApiAttributeErrors(diags *diag.Diagnostics, summary string, p path.Path, t attr.Type, v any) int {
+	switch vv := v.(type) {
 	case map[string]any:
-		newMap := make(map[string]any, len(v))
-		for key, val := range v {
-			newMap[convertFn(key)] = convertMapKeysRecursive(val, convertFn)
+		if oneOf, ok := t.(is.OneOfType); ok {
+			// VMS reports errors of polymorphic value in its flat API shape.
+			if variant := oneOf.MatchVariant(vv); variant != "" {
+				return addApiAttributeErrors(diags, summary, p.AtName(variant), oneOf.AttrTypes[variant], vv)
+			}
+		}
+		count := 0
+		for _, k := range slices.Sorted(maps.Keys(vv)) {
+			switch tt := t.(type) {
+			case attr.TypeWithAttributeTypes:
+				if name, ok := apiErrorAttributeName(tt, k); ok {
+					count += addApiAttributeErrors(diags, summary, p.AtName(name), tt.AttributeTypes()[name], vv[k])
+					continue
+				}
+			case basetypes.MapTypable:
+				if mapType, ok := tt.(attr.TypeWithElementType); ok {
+					count += addApiAttributeErrors(diags, summary, p.AtMapKey(k), mapType.ElementType(), vv[k])
+					continue
+				}
+			case basetypes.ListTypable:
+				// Per-item errors keyed by index: {"0": ["message"]}.
+				if listType, ok := tt.(attr.TypeWithElementType); ok {
+					if i, err := strconv.Atoi(k); err == nil {
+						count += addApiAttributeErrors(diags, summary, p.AtListIndex(i), listType.ElementType(), vv[k])
+						continue
+					}
+				}
+			}
+			diags.AddAttributeError(p, summary, fmt.Sprintf("%s: %s", k, strings.Join(apiErrorMessages(vv[k]), "; ")))
+			count++
 		}
-		return newMap
+		return count
 	case []any:
-		for i, item := range v {
-			v[i] = convertMapKeysRecursive(item, convertFn)
+		_, isList := t.(basetypes.ListTypable)
+		listType, hasElem := t.(attr.TypeWithElementType)
+		if !isList || !hasElem || !slices.ContainsFunc(vv, isApiErrorContainer) {
+			break
 		}
-		return v
-	default:
-		return val
+		// Per-item errors: [{}, {"field": ["message"]}].
+		count := 0
+		for i, item := range vv {
+			if len(apiErrorMessages(item)) == 0 {
+				continue
+			}
+			count += addApiAttributeErrors(diags, summary, p.AtListIndex(i), listType.ElementType(), item)
+		}
+		return count
+	}
+
+	messages := apiErrorMessages(v)
+	if len(messages) == 0 {
+		return 0
 	}
+	diags.AddAttributeError(p, summary, strings.Join(messages, "\n"))
+	return 1
 }
 
-// underscoreToDash converts a string from snake_case to dash-case.
-// Example: "start_at" → "start-at"
-func underscoreToDash(s string) string {
-	return strings.ReplaceAll(s, "_", "-")
+// apiErrorAttributeName returns Terraform name of attribute of object type t reported by VMS under API name k.
+func apiErrorAttributeName(t attr.TypeWithAttributeTypes, k string) (string, bool) {
+	if renamed, ok := t.(is.RenamedObjectType); ok {
+		for name, apiName := range renamed.APINames {
+			if apiName == k {
+				return name, true
+			}
+		}
+	}
+	_, ok := t.AttributeTypes()[k]
+	return k, ok
 }
 
-// dashToUnderscore converts a string from dash-case to snake_case.
-// Example: "start-at" → "start_at"
-func dashToUnderscore(s string) string {
-	return strings.ReplaceAll(s, "-", "_")
+func isApiErrorContainer(v any) bool {
+	switch v.(type) {
+	case map[string]any, []any:
+		return true
+	}
+	return false
+}
+
+// apiErrorMessages flattens value of VMS error payload into list of messages.
+func apiErrorMessages(v any) []string {
+	switch vv := v.(type) {
+	case nil:
+		return nil
+	case string:
+		if vv == "" {
+			return nil
+		}
+		return []string{vv}
+	case []any:
+		var out []string
+		for _, item := range vv {
+			out = append(out, apiErrorMessages(item)...)
+		}
+		return out
+	case map[string]any:
+		var out []string
+		for _, k := range slices.Sorted(maps.Keys(vv)) {
+			if messages := apiErrorMessages(vv[k]); len(messages) > 0 {
+				out = append(out, fmt.Sprintf("%s: %s", k, strings.Join(messages, "; ")))
+			}
+		}
+		return out
+	default:
+		return []string{fmt.Sprint(vv)}
+	}
 }
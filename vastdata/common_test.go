@@ -37,120 +37,6 @@ func TestNormalizeNumber(t *testing.T) {
 	assert.Equal(t, expectedMap, normalizeNumber(inputMap))
 }
 
-// ---------- convertMapKeysRecursive ----------
-
-func TestConvertMapKeysRecursive_UnderscoreToDash(t *testing.T) {
-	input := map[string]any{
-		"start_at": "val",
-		"nested": map[string]any{
-			"keep_local": "val2",
-		},
-	}
-	expected := map[string]any{
-		"start-at": "val",
-		"nested": map[string]any{
-			"keep-local": "val2",
-		},
-	}
-	out := convertMapKeysRecursive(input, underscoreToDash)
-	assert.Equal(t, expected, out)
-}
-
-func TestConvertMapKeysRecursive_DashToUnderscore(t *testing.T) {
-	input := map[string]any{
-		"start-at": "val",
-		"nested": map[string]any{
-			"keep-local": "val2",
-		},
-	}
-	expected := map[string]any{
-		"start_at": "val",
-		"nested": map[string]any{
-			"keep_local": "val2",
-		},
-	}
-	out := convertMapKeysRecursive(input, dashToUnderscore)
-	assert.Equal(t, expected, out)
-}
-
-// ---------- underscoreToDash / dashToUnderscore ----------
-
-func TestKeyTransformHelpers(t *testing.T) {
-	assert.Equal(t, "start-at", underscoreToDash("start_at"))
-	assert.Equal(t, "keep_local", dashToUnderscore("keep-local"))
-}
-
-// ---------- validateOneOf / validateAllOf / validateNoneOf ----------
-
-func TestValidateOneOf(t *testing.T) {
-	tf := mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringNull(), // defined, but null initially
-		"c": types.StringNull(),
-	})
-
-	err := validateOneOf(tf, "a", "b", "c")
-	assert.NoError(t, err)
-
-	// Create new TFState with both a and b set
-	tf = mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringValue("y"),
-		"c": types.StringNull(),
-	})
-	err = validateOneOf(tf, "a", "b", "c")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "only one of")
-}
-
-func TestValidateAllOf(t *testing.T) {
-	tf := mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringValue("y"),
-		"c": types.StringNull(), // present, but intentionally null
-	})
-	err := validateAllOf(tf, "a", "b", "c")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), "must be set")
-
-	// Now make all present
-	tf = mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringValue("y"),
-		"c": types.StringValue("z"),
-	})
-	err = validateAllOf(tf, "a", "b", "c")
-	assert.NoError(t, err)
-}
-
-func TestValidateNoneOf(t *testing.T) {
-	tf := mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringValue("y"),
-	})
-
-	err := validateNoneOf(tf, "a", "b")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), `none of ["a" "b"] should be set`)
-	assert.Contains(t, err.Error(), `[a b]`)
-
-	tf = mustTFState(map[string]attr.Value{
-		"a": types.StringValue("x"),
-		"b": types.StringNull(), // explicitly null
-	})
-	err = validateNoneOf(tf, "a", "b")
-	assert.Error(t, err)
-	assert.Contains(t, err.Error(), `none of ["a" "b"] should be set`)
-	assert.Contains(t, err.Error(), `[a]`)
-
-	tf = mustTFState(map[string]attr.Value{
-		"a": types.StringNull(),
-		"b": types.StringNull(),
-	})
-	err = validateNoneOf(tf, "a", "b")
-	assert.NoError(t, err)
-}
-
 // ---------- TFState: Getters ----------
 
 func TestTFState_String(t *testing.T) {
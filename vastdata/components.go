@@ -7,6 +7,7 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
@@ -66,6 +67,13 @@ var allTFComponents = []TFManager{
 	&BlockHost{},
 }
 
+// allEphemeralComponents holds components that can also be opened as ephemeral resources
+// (short-lived credentials which are created on Open and removed on Close).
+var allEphemeralComponents = []EphemeralResourceManager{
+	&ApiToken{},
+	&UserKey{},
+}
+
 // GetResourceFactories returns a list of factory functions that instantiate
 // Terraform resources supported by the provider.
 //
@@ -113,6 +121,24 @@ func GetDatasourceFactories() []func() datasource.DataSource {
 
 }
 
+// GetEphemeralResourceFactories returns a list of factory functions that instantiate
+// Terraform ephemeral resources supported by the provider.
+func GetEphemeralResourceFactories() []func() ephemeral.EphemeralResource {
+	var factories []func() ephemeral.EphemeralResource
+	for _, f := range allEphemeralComponents {
+		managerFn := f.NewResourceManager
+		managerType := is.SnakeCaseName(f)
+
+		factories = append(factories, func() ephemeral.EphemeralResource {
+			return &EphemeralResource{
+				newManager:  managerFn,
+				managerName: managerType,
+			}
+		})
+	}
+	return factories
+}
+
 type ResourceFactoryFn func(raw map[string]attr.Value, schema any) ResourceManager
 type DatasourceFactoryFn func(raw map[string]attr.Value, schema any) DataSourceManager
 
@@ -198,6 +224,13 @@ type ResourceManager interface {
 	NewResourceManager(raw map[string]attr.Value, schema any) ResourceManager
 }
 
+// EphemeralResourceManager is a resource which can be exposed as ephemeral resource.
+// DeleteResource is used to revoke the credential on Close.
+type EphemeralResourceManager interface {
+	ResourceManager
+	DeleteResource
+}
+
 // -----------------
 // Datasource interfaces
 // -----------------
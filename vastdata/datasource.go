@@ -31,7 +31,7 @@ func (d *Datasource) ManagerWithSchemaOnly(ctx context.Context) (DataSourceManag
 	// Get schema for the datasource to create a proper manager
 	emptyManager := d.newManager(nil, nil)
 	hints := emptyManager.TfState().Hints
-	schema, err := schema_generation.GetDatasourceSchema(ctx, hints)
+	schema, err := schema_generation.GetCachedDatasourceSchema(ctx, d.managerName, hints)
 	if err != nil {
 		return nil, err
 	}
@@ -79,6 +79,10 @@ func (d *Datasource) Configure(ctx context.Context, req datasource.ConfigureRequ
 	})
 }
 
+func (d *Datasource) ConfigValidators(_ context.Context) []datasource.ConfigValidator {
+	return schema_generation.DatasourceConfigValidators(d.EmptyManager().TfState().Hints)
+}
+
 func (d *Datasource) Read(ctx context.Context, req datasource.ReadRequest, resp *datasource.ReadResponse) {
 	withContext(ctx, "Read", d.managerName, func(ctx context.Context) {
 		d.readImpl(ctx, req, resp)
@@ -151,9 +155,11 @@ func (d *Datasource) readImpl(ctx context.Context, req datasource.ReadRequest, r
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics(
+			&resp.Diagnostics,
 			fmt.Sprintf("Read[%s]: error reading datasource.", managerName),
-			err.Error(),
+			err,
+			tfState.TypeMap,
 		)
 		return
 	}
@@ -23,7 +23,8 @@ func (m *Dns) NewResourceManager(raw map[string]attr.Value, schema any) Resource
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: DnsSchemaRef,
+			SchemaRef:      DnsSchemaRef,
+			VolatileFields: []string{"sync_time"},
 		},
 	)}
 }
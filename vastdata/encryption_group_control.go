@@ -4,11 +4,13 @@ package provider
 import (
 	"context"
 	"fmt"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	planmodifiers "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
 
@@ -37,6 +39,9 @@ func (m *EncryptionGroupControl) NewResourceManager(raw map[string]attr.Value, s
 						PlanModifiers: []planmodifiers.String{
 							stringplanmodifier.RequiresReplace(),
 						},
+						Validators: []validator.String{
+							stringvalidator.OneOf("revoke", "deactivate", "reinstate", "rotate_key"),
+						},
 					},
 				},
 			},
@@ -52,10 +57,6 @@ func (m *EncryptionGroupControl) API(rest *VMSRest) VastResourceAPIWithContext {
 	return rest.EncryptionGroups
 }
 
-func (m *EncryptionGroupControl) ValidateResourceConfig(context.Context) error {
-	return ValidateFieldIsOneOf(m.tfstate, "action", "revoke", "deactivate", "reinstate", "rotate_key")
-}
-
 func (m *EncryptionGroupControl) CreateResource(ctx context.Context, rest *VMSRest) (DisplayableRecord, error) {
 	return m.performAction(ctx, rest)
 }
@@ -0,0 +1,269 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/schema_generation"
+)
+
+// ephemeralPrivateKey is the private data key holding identity of the credential created on Open.
+const ephemeralPrivateKey = "vastdata_ephemeral_identity"
+
+// EphemeralResource exposes a short-lived credential (Terraform 1.10+).
+// The credential is created on Open, is never persisted to plan or state
+// and is removed on Close using the DeleteResource implementation of the underlying component.
+type EphemeralResource struct {
+	newManager  ResourceFactoryFn
+	client      *VMSRest
+	managerName string
+}
+
+// NewManager builds a resource manager (backed by the resource schema) from ephemeral values.
+func (e *EphemeralResource) NewManager(ctx context.Context, raw map[string]attr.Value) (ResourceManager, error) {
+	hints := e.newManager(nil, nil).TfState().Hints
+	schema, err := schema_generation.GetCachedResourceSchema(ctx, e.managerName, hints)
+	if err != nil {
+		return nil, err
+	}
+	if raw == nil {
+		raw = make(map[string]attr.Value)
+	}
+	for k, a := range schema.Attributes {
+		if _, ok := raw[k]; !ok {
+			raw[k], _ = is.BuildAttrValueFromAny(a.GetType(), nil)
+		}
+	}
+	return e.newManager(raw, *schema), nil
+}
+
+// ----------------------------------------
+//   EPHEMERAL RESOURCE INTERFACE IMPLEMENTATION
+// ----------------------------------------
+
+func (e *EphemeralResource) Metadata(ctx context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	withContext(ctx, "Metadata", e.managerName, func(ctx context.Context) {
+		e.metadataImpl(ctx, req, resp)
+	})
+}
+
+func (e *EphemeralResource) Schema(ctx context.Context, req ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	withContext(ctx, "Schema", e.managerName, func(ctx context.Context) {
+		e.schemaImpl(ctx, req, resp)
+	})
+}
+
+func (e *EphemeralResource) Configure(ctx context.Context, req ephemeral.ConfigureRequest, resp *ephemeral.ConfigureResponse) {
+	withContext(ctx, "Configure", e.managerName, func(ctx context.Context) {
+		e.configureImpl(ctx, req, resp)
+	})
+}
+
+func (e *EphemeralResource) Open(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	withContext(ctx, "Open", e.managerName, func(ctx context.Context) {
+		e.openImpl(ctx, req, resp)
+	})
+}
+
+func (e *EphemeralResource) Close(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	withContext(ctx, "Close", e.managerName, func(ctx context.Context) {
+		e.closeImpl(ctx, req, resp)
+	})
+}
+
+// ----------------------------------------
+
+func (e *EphemeralResource) metadataImpl(_ context.Context, req ephemeral.MetadataRequest, resp *ephemeral.MetadataResponse) {
+	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, e.managerName)
+}
+
+func (e *EphemeralResource) schemaImpl(ctx context.Context, _ ephemeral.SchemaRequest, resp *ephemeral.SchemaResponse) {
+	hints := e.newManager(nil, nil).TfState().Hints
+	schema, err := schema_generation.GetEphemeralSchema(ctx, hints)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error getting schema for %q ephemeral resource.", e.managerName),
+			err.Error(),
+		)
+		return
+	}
+	resp.Schema = *schema
+}
+
+func (e *EphemeralResource) configureImpl(_ context.Context, req ephemeral.ConfigureRequest, _ *ephemeral.ConfigureResponse) {
+	if req.ProviderData == nil {
+		return
+	}
+	e.client = req.ProviderData.(*VMSRest)
+}
+
+func (e *EphemeralResource) openImpl(ctx context.Context, req ephemeral.OpenRequest, resp *ephemeral.OpenResponse) {
+	var (
+		rest        = e.client
+		managerName = e.managerName
+		record      DisplayableRecord
+	)
+
+	values, err := is.FillFrameworkValues(req.Config.Raw, req.Config.Schema)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Open[%s]: error reading config.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	manager, err := e.NewManager(ctx, values)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error fetching OpenAPI schema for %q ephemeral resource.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	tfState := manager.TfState()
+
+	if !resolveReferenceFields(ctx, componentFinder(rest), tfState, &resp.Diagnostics) {
+		return
+	}
+
+	if imp, ok := manager.(PrepareCreateResource); ok {
+		tflog.Debug(ctx, fmt.Sprintf("PrepareCreateResource[%s]: do.", managerName))
+		if err = imp.PrepareCreateResource(ctx, rest); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("PrepareCreateResource[%q]", managerName),
+				err.Error(),
+			)
+			return
+		}
+	}
+
+	// Ephemeral credentials are always new: never take management of an existing object.
+	if imp, ok := manager.(CreateResource); ok {
+		tflog.Debug(ctx, fmt.Sprintf("CreateResource[%s]: do.", managerName))
+		record, err = imp.CreateResource(ctx, rest)
+	} else {
+		tflog.Debug(ctx, fmt.Sprintf("Open[%s]: use default implementation.", managerName))
+		createParams := tfState.GetCreateParams()
+		if transformer, ok := manager.(TransformRequestBody); ok {
+			createParams = transformer.TransformRequestBody(createParams)
+		}
+		record, err = manager.API(rest).CreateWithContext(ctx, createParams)
+	}
+	if err != nil {
+		addApiErrorDiagnostics(
+			&resp.Diagnostics,
+			fmt.Sprintf("error creating %q ephemeral resource.", managerName),
+			err,
+			tfState.TypeMap,
+		)
+		return
+	}
+
+	if record != nil {
+		if transformer, ok := manager.(TransformResponseRecord); ok {
+			record = transformer.TransformResponseRecord(record.(Record))
+		}
+		if err = tfState.FillFromRecord(record.(Record)); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("error filling %q ephemeral resource.", managerName),
+				err.Error(),
+			)
+		}
+	}
+
+	if !resp.Diagnostics.HasError() {
+		if err = tfState.SetEphemeralResult(ctx, &resp.Result); err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("error setting result for %q ephemeral resource.", managerName),
+				err.Error(),
+			)
+		}
+	}
+
+	if !resp.Diagnostics.HasError() {
+		private, err := ephemeralPrivateData(tfState)
+		if err != nil {
+			resp.Diagnostics.AddError(
+				fmt.Sprintf("Open[%s]: error building private data.", managerName),
+				err.Error(),
+			)
+		} else {
+			resp.Diagnostics.Append(resp.Private.SetKey(ctx, ephemeralPrivateKey, private)...)
+		}
+	}
+
+	if resp.Diagnostics.HasError() {
+		// Do not leave orphaned credentials behind: Close is not called when Open fails.
+		if err = manager.(DeleteResource).DeleteResource(ctx, rest); err != nil {
+			tflog.Warn(ctx, fmt.Sprintf("Open[%s]: error deleting credential after error: %s", managerName, err.Error()))
+		}
+	}
+}
+
+func (e *EphemeralResource) closeImpl(ctx context.Context, req ephemeral.CloseRequest, resp *ephemeral.CloseResponse) {
+	managerName := e.managerName
+
+	private, diags := req.Private.GetKey(ctx, ephemeralPrivateKey)
+	resp.Diagnostics.Append(diags...)
+	if resp.Diagnostics.HasError() {
+		return
+	}
+	if private == nil {
+		tflog.Debug(ctx, fmt.Sprintf("Close[%s]: nothing to revoke.", managerName))
+		return
+	}
+
+	manager, err := e.managerFromPrivateData(ctx, private)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Close[%s]: error reading private data.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	if err = manager.(DeleteResource).DeleteResource(ctx, e.client); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error revoking %q ephemeral resource.", managerName),
+			err.Error(),
+		)
+	}
+}
+
+// ephemeralPrivateData serializes identity fields required to remove the credential on Close.
+// Secrets are never part of private data.
+func ephemeralPrivateData(tfState *is.TFState) ([]byte, error) {
+	data := make(map[string]any)
+	for _, k := range schema_generation.IdentityFields(tfState.Hints) {
+		v, ok := tfState.Raw[k]
+		if !ok || v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		data[k] = is.ConvertAttrValueToRaw(v, tfState.Type(k))
+	}
+	if len(data) == 0 {
+		return nil, fmt.Errorf("none of identity fields %v are set", schema_generation.IdentityFields(tfState.Hints))
+	}
+	return json.Marshal(data)
+}
+
+func (e *EphemeralResource) managerFromPrivateData(ctx context.Context, private []byte) (ResourceManager, error) {
+	data := make(map[string]any)
+	if err := json.Unmarshal(private, &data); err != nil {
+		return nil, err
+	}
+	manager, err := e.NewManager(ctx, nil)
+	if err != nil {
+		return nil, err
+	}
+	if err = manager.TfState().FillFromRawState(data); err != nil {
+		return nil, err
+	}
+	return manager, nil
+}
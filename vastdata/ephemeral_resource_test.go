@@ -0,0 +1,90 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func findEphemeralResource(t *testing.T, managerName string) *EphemeralResource {
+	for _, f := range GetEphemeralResourceFactories() {
+		if res := f().(*EphemeralResource); res.managerName == managerName {
+			return res
+		}
+	}
+	t.Fatalf("ephemeral resource %q is not registered", managerName)
+	return nil
+}
+
+func TestEphemeralResource_Schemas(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		managerName string
+		typeName    string
+		secret      string
+	}{
+		{managerName: "api_token", typeName: "vastdata_api_token", secret: "token"},
+		{managerName: "user_key", typeName: "vastdata_user_key", secret: "secret_key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.managerName, func(t *testing.T) {
+			e := findEphemeralResource(t, tt.managerName)
+
+			metaResp := &ephemeral.MetadataResponse{}
+			e.Metadata(ctx, ephemeral.MetadataRequest{ProviderTypeName: "vastdata"}, metaResp)
+			assert.Equal(t, tt.typeName, metaResp.TypeName)
+
+			schemaResp := &ephemeral.SchemaResponse{}
+			e.Schema(ctx, ephemeral.SchemaRequest{}, schemaResp)
+			require.False(t, schemaResp.Diagnostics.HasError(), "%v", schemaResp.Diagnostics)
+			secret, ok := schemaResp.Schema.Attributes[tt.secret]
+			require.True(t, ok, "missing %q attribute", tt.secret)
+			assert.True(t, secret.IsComputed())
+		})
+	}
+}
+
+func TestEphemeralResource_PrivateDataRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	e := findEphemeralResource(t, "user_key")
+	manager, err := e.NewManager(ctx, map[string]attr.Value{
+		"user_id":    types.Int64Value(3),
+		"access_key": types.StringValue("AK"),
+		"secret_key": types.StringValue("SK"),
+	})
+	require.NoError(t, err)
+
+	private, err := ephemeralPrivateData(manager.TfState())
+	require.NoError(t, err)
+	assert.JSONEq(t, `{"user_id": 3, "access_key": "AK"}`, string(private))
+	assert.NotContains(t, string(private), "SK")
+
+	restored, err := e.managerFromPrivateData(ctx, private)
+	require.NoError(t, err)
+	ts := restored.TfState()
+	assert.Equal(t, int64(3), ts.Int64("user_id"))
+	assert.Equal(t, "AK", ts.String("access_key"))
+	assert.True(t, ts.IsNull("secret_key"))
+}
+
+func TestEphemeralResource_PrivateDataRequiresIdentity(t *testing.T) {
+	e := findEphemeralResource(t, "api_token")
+	manager, err := e.NewManager(context.Background(), nil)
+	require.NoError(t, err)
+	_, err = ephemeralPrivateData(manager.TfState())
+	require.Error(t, err)
+}
+
+func TestEphemeralResource_CloseWithoutPrivateData(t *testing.T) {
+	e := findEphemeralResource(t, "api_token")
+	resp := &ephemeral.CloseResponse{}
+	e.Close(context.Background(), ephemeral.CloseRequest{}, resp)
+	assert.False(t, resp.Diagnostics.HasError())
+}
@@ -12,6 +12,7 @@ import (
 	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
@@ -761,3 +762,427 @@ func TestErrorHandling_ErrorMessageQuality(t *testing.T) {
 		})
 	}
 }
+
+func TestErrorHandling_ApiErrorAttributeDiagnostics(t *testing.T) {
+	typeMap := map[string]attr.Type{
+		"name":  types.StringType,
+		"path":  types.StringType,
+		"hosts": types.ListType{ElemType: types.StringType},
+		"share_acl": types.ObjectType{AttrTypes: map[string]attr.Type{
+			"enabled": types.BoolType,
+			"acl": types.ListType{ElemType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"grantee": types.StringType,
+				"name":    types.StringType,
+			}}},
+		}},
+		"tags": types.MapType{ElemType: types.StringType},
+		"frames": types.ListType{ElemType: is.NewRenamedObjectType(
+			map[string]attr.Type{"start_at": types.StringType, "every": types.StringType},
+			map[string]string{"start_at": "start-at"},
+		)},
+		"rule": is.OneOfType{
+			ObjectType: types.ObjectType{AttrTypes: map[string]attr.Type{
+				"by_age":  types.ObjectType{AttrTypes: map[string]attr.Type{"days": types.Int64Type}},
+				"by_date": types.ObjectType{AttrTypes: map[string]attr.Type{"date": types.StringType}},
+			}},
+		},
+		"ip_ranges": is.NewIPRangeListType(),
+	}
+
+	tests := []struct {
+		name          string
+		err           error
+		expectPaths   []string // paths of attribute diagnostics
+		expectGeneral []string // details of general diagnostics
+	}{
+		{
+			name:          "field_messages",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"name": ["This field is required."], "path": ["Path must start with '/'."]}`},
+			expectPaths:   []string{"name", "path"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "field_messages_with_detail",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"name": ["view with this name already exists."], "detail": "Invalid input."}`},
+			expectPaths:   []string{"name"},
+			expectGeneral: []string{"Invalid input."},
+		},
+		{
+			name: "nested_object_and_list_items",
+			err: &ApiError{StatusCode: http.StatusBadRequest, Body: `{"share_acl": {"enabled": ["Must be a valid boolean."],` +
+				` "acl": [{}, {"grantee": ["\"xxx\" is not a valid choice."]}]}}`},
+			expectPaths:   []string{"share_acl.acl[1].grantee", "share_acl.enabled"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "list_items_by_index",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"hosts": {"2": ["Enter a valid IPv4 address."]}}`},
+			expectPaths:   []string{"hosts[2]"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "map_keys",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"tags": {"env": ["Too long."]}}`},
+			expectPaths:   []string{`tags["env"]`},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "renamed_object_fields",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"frames": [{"start-at": ["Invalid date format."], "every": ["Required."]}]}`},
+			expectPaths:   []string{"frames[0].start_at", "frames[0].every"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "oneof_flat_variant",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"rule": {"days": ["Ensure this value is greater than 0."]}}`},
+			expectPaths:   []string{"rule.by_age.days"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "ip_range_items",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"ip_ranges": {"1": {"0": ["Enter a valid IPv4 address."]}}}`},
+			expectPaths:   []string{"ip_ranges[1][0]"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:          "unknown_field_is_general",
+			err:           &ApiError{StatusCode: http.StatusBadRequest, Body: `{"name": ["Invalid."], "tenant": ["Not found."]}`},
+			expectPaths:   []string{"name"},
+			expectGeneral: []string{"tenant: Not found."},
+		},
+		{
+			name:          "detail_only",
+			err:           &ApiError{StatusCode: http.StatusConflict, Body: `{"detail": "Policy is in use."}`},
+			expectGeneral: []string{"Policy is in use."},
+		},
+		{
+			name:          "not_json_body",
+			err:           &ApiError{StatusCode: http.StatusInternalServerError, Body: "Internal Server Error"},
+			expectGeneral: []string{"Internal Server Error"},
+		},
+		{
+			name:          "not_api_error",
+			err:           errors.New("connection refused"),
+			expectGeneral: []string{"connection refused"},
+		},
+		{
+			name:          "wrapped_api_error",
+			err:           fmt.Errorf("create: %w", &ApiError{StatusCode: http.StatusBadRequest, Body: `{"name": ["Invalid."]}`}),
+			expectPaths:   []string{"name"},
+			expectGeneral: []string{"[validation]"},
+		},
+		{
+			name:        "unclassified_attribute_errors",
+			err:         &ApiError{StatusCode: http.StatusNotFound, Body: `{"name": ["Not found."]}`},
+			expectPaths: []string{"name"},
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			var diags diag.Diagnostics
+			addApiErrorDiagnostics(&diags, "error creating \"test\" resource.", tt.err, typeMap)
+			require.True(t, diags.HasError())
+
+			var (
+				paths   []string
+				general []string
+			)
+			for _, d := range diags {
+				require.Equal(t, "error creating \"test\" resource.", d.Summary())
+				if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+					paths = append(paths, withPath.Path().String())
+					require.NotEmpty(t, d.Detail())
+				} else {
+					general = append(general, d.Detail())
+				}
+			}
+			require.ElementsMatch(t, tt.expectPaths, paths)
+			require.Len(t, general, len(tt.expectGeneral))
+			for i, detail := range tt.expectGeneral {
+				require.Contains(t, general[i], detail)
+			}
+		})
+	}
+}
+
+func TestErrorHandling_ClassifyApiError(t *testing.T) {
+	// Payloads recorded from VMS responses.
+	tests := []struct {
+		name              string
+		err               error
+		expectCategory    ApiErrorCategory
+		expectRemediation []string
+	}{
+		{
+			name:              "unauthorized",
+			err:               &ApiError{StatusCode: http.StatusUnauthorized, Body: `{"detail": "Authentication credentials were not provided."}`},
+			expectCategory:    ApiErrorAuth,
+			expectRemediation: []string{"api_token"},
+		},
+		{
+			name:              "expired_token",
+			err:               &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "Given token is invalid or expired"}`},
+			expectCategory:    ApiErrorAuth,
+			expectRemediation: []string{"revoked"},
+		},
+		{
+			name:              "forbidden_with_realm",
+			err:               &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "Manager admin1 does not have 'create' permission for realm 'security'"}`},
+			expectCategory:    ApiErrorPermission,
+			expectRemediation: []string{`"create" permission`, `"security" realm`},
+		},
+		{
+			name:              "forbidden_realm_only",
+			err:               &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "Access to realm: logical is not allowed"}`},
+			expectCategory:    ApiErrorPermission,
+			expectRemediation: []string{`"logical" realm`},
+		},
+		{
+			name:              "forbidden_generic",
+			err:               &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "You do not have permission to perform this action."}`},
+			expectCategory:    ApiErrorPermission,
+			expectRemediation: []string{"vastdata_administrator_role"},
+		},
+		{
+			name:              "license",
+			err:               &ApiError{StatusCode: http.StatusBadRequest, Body: `{"detail": "Replication is not supported by the current license"}`},
+			expectCategory:    ApiErrorLicense,
+			expectRemediation: []string{"License"},
+		},
+		{
+			name:              "license_expired",
+			err:               &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "Cluster license has expired"}`},
+			expectCategory:    ApiErrorLicense,
+			expectRemediation: []string{"License"},
+		},
+		{
+			name:              "license_field_validation",
+			err:               &ApiError{StatusCode: http.StatusBadRequest, Body: `{"license_key": ["This field is required."]}`},
+			expectCategory:    ApiErrorValidation,
+			expectRemediation: []string{"VMS version"},
+		},
+		{
+			name:              "license_server_error",
+			err:               &ApiError{StatusCode: http.StatusInternalServerError, Body: `{"detail": "Failed to read license file"}`},
+			expectCategory:    ApiErrorServer,
+			expectRemediation: []string{"Retry later"},
+		},
+		{
+			name:              "tenant_mismatch",
+			err:               &ApiError{StatusCode: http.StatusBadRequest, Body: `{"policy_id": ["View policy 3 does not belong to tenant 2"]}`},
+			expectCategory:    ApiErrorValidation,
+			expectRemediation: []string{"tenant_id"},
+		},
+		{
+			name:              "in_use_conflict",
+			err:               &ApiError{StatusCode: http.StatusConflict, Body: `{"detail": "Policy default2 is in use by 3 views"}`},
+			expectCategory:    ApiErrorConflict,
+			expectRemediation: []string{"depends_on"},
+		},
+		{
+			name:              "in_use_bad_request",
+			err:               &ApiError{StatusCode: http.StatusBadRequest, Body: `{"detail": "Cannot delete QoS policy, it is being used by views"}`},
+			expectCategory:    ApiErrorConflict,
+			expectRemediation: []string{"dependent objects"},
+		},
+		{
+			name:              "already_exists",
+			err:               &ApiError{StatusCode: http.StatusConflict, Body: `{"detail": "User with this name already exists"}`},
+			expectCategory:    ApiErrorConflict,
+			expectRemediation: []string{"Import"},
+		},
+		{
+			name:              "validation",
+			err:               &ApiError{StatusCode: http.StatusBadRequest, Body: `{"path": ["Path must be absolute"]}`},
+			expectCategory:    ApiErrorValidation,
+			expectRemediation: []string{"VMS version"},
+		},
+		{
+			name:              "server_error",
+			err:               &ApiError{StatusCode: http.StatusServiceUnavailable, Body: "<html>503 Service Unavailable</html>"},
+			expectCategory:    ApiErrorServer,
+			expectRemediation: []string{"Retry later"},
+		},
+		{
+			name:           "not_found_is_unknown",
+			err:            &ApiError{StatusCode: http.StatusNotFound, Body: `{"detail": "Not found."}`},
+			expectCategory: ApiErrorUnknown,
+		},
+		{
+			name:           "not_api_error",
+			err:            errors.New("connection refused"),
+			expectCategory: ApiErrorUnknown,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			category, remediation := classifyApiError(tt.err)
+			require.Equal(t, tt.expectCategory, category)
+			if tt.expectCategory == ApiErrorUnknown {
+				require.Empty(t, remediation)
+				require.Equal(t, tt.err.Error(), withRemediation(tt.err.Error(), tt.err))
+				return
+			}
+			for _, s := range tt.expectRemediation {
+				require.Contains(t, remediation, s)
+			}
+			require.Contains(t, withRemediation("detail", tt.err), fmt.Sprintf("[%s] %s", category, remediation))
+		})
+	}
+}
+
+func TestErrorHandling_ApiErrorDiagnosticsRemediation(t *testing.T) {
+	var diags diag.Diagnostics
+	err := &ApiError{StatusCode: http.StatusConflict, Body: `{"detail": "Policy default2 is in use by 3 views"}`}
+	addApiErrorDiagnostics(&diags, "Delete[view_policy]", err, map[string]attr.Type{"name": types.StringType})
+	require.Len(t, diags, 1)
+	require.Contains(t, diags[0].Detail(), "Policy default2 is in use by 3 views")
+	require.Contains(t, diags[0].Detail(), "[conflict]")
+
+	// Remediation is reported separately when all messages are bound to attributes.
+	diags = nil
+	err = &ApiError{StatusCode: http.StatusBadRequest, Body: `{"name": ["View policy 3 does not belong to tenant 2"]}`}
+	addApiErrorDiagnostics(&diags, "Create[view]", err, map[string]attr.Type{"name": types.StringType})
+	require.Len(t, diags, 2)
+	_, withPath := diags[0].(diag.DiagnosticWithPath)
+	require.True(t, withPath)
+	require.Contains(t, diags[1].Detail(), "[validation]")
+	require.Contains(t, diags[1].Detail(), "tenant_id")
+}
+
+func withShortDeleteConflictBackoff(t *testing.T) {
+	initial, maxBackoff := deleteConflictInitialBackoff, deleteConflictMaxBackoff
+	deleteConflictInitialBackoff, deleteConflictMaxBackoff = time.Millisecond, 4*time.Millisecond
+	t.Cleanup(func() {
+		deleteConflictInitialBackoff, deleteConflictMaxBackoff = initial, maxBackoff
+	})
+}
+
+func TestErrorHandling_RetryOnDependencyConflict(t *testing.T) {
+	withShortDeleteConflictBackoff(t)
+	inUse := &ApiError{StatusCode: http.StatusConflict, Body: `{"detail": "Policy p1 is in use by 1 views"}`}
+
+	t.Run("succeeds_once_dependents_are_gone", func(t *testing.T) {
+		calls := 0
+		err := retryOnDependencyConflict(context.Background(), time.Second, "view_policy", "Delete", func() error {
+			calls++
+			if calls < 3 {
+				return inUse
+			}
+			return nil
+		})
+		require.NoError(t, err)
+		require.Equal(t, 3, calls)
+	})
+
+	t.Run("gives_up_after_timeout", func(t *testing.T) {
+		calls := 0
+		start := time.Now()
+		err := retryOnDependencyConflict(context.Background(), 30*time.Millisecond, "view_policy", "Delete", func() error {
+			calls++
+			return inUse
+		})
+		require.ErrorIs(t, err, inUse)
+		require.Greater(t, calls, 1)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("honors_context_deadline", func(t *testing.T) {
+		ctx, cancel := context.WithTimeout(context.Background(), 20*time.Millisecond)
+		defer cancel()
+		start := time.Now()
+		err := retryOnDependencyConflict(ctx, time.Hour, "view_policy", "Delete", func() error { return inUse })
+		require.ErrorIs(t, err, inUse)
+		require.Less(t, time.Since(start), time.Second)
+	})
+
+	t.Run("other_errors_are_not_retried", func(t *testing.T) {
+		calls := 0
+		forbidden := &ApiError{StatusCode: http.StatusForbidden, Body: `{"detail": "You do not have permission to perform this action."}`}
+		err := retryOnDependencyConflict(context.Background(), time.Second, "view_policy", "Delete", func() error {
+			calls++
+			return forbidden
+		})
+		require.ErrorIs(t, err, forbidden)
+		require.Equal(t, 1, calls)
+	})
+}
+
+func TestErrorHandling_FindDependents(t *testing.T) {
+	vms := map[string]RecordSet{
+		"view": {
+			{"id": float64(1), "path": "/a", "policy_id": float64(5)},
+			{"id": float64(2), "name": "b", "path": "/b", "policy_id": float64(5)},
+			// Server side filtering is not relied upon.
+			{"id": float64(3), "path": "/c", "policy_id": float64(6)},
+		},
+		"tenant": {
+			{"id": float64(7), "name": "t1", "s3_policies_ids": []any{float64(4), float64(5)}},
+		},
+	}
+	list := func(_ context.Context, component string, _ params) (RecordSet, error) {
+		records, ok := vms[component]
+		if !ok {
+			return nil, fmt.Errorf("unknown component %q", component)
+		}
+		return records, nil
+	}
+
+	dependents := findDependents(context.Background(), list, []is.DependentReference{
+		{Component: "view", Field: "policy_id"},
+		{Component: "tenant", Field: "s3_policies_ids"},
+		{Component: "unknown", Field: "policy_id"},
+	}, int64(5))
+	require.Equal(t, []string{
+		`view "/a" (id=1, policy_id=5)`,
+		`view "b" (id=2, policy_id=5)`,
+		`tenant "t1" (id=7, s3_policies_ids=5)`,
+	}, dependents)
+}
+
+func TestErrorHandling_DeleteRetryOnConflictHints(t *testing.T) {
+	ctx := context.Background()
+	for _, f := range allTFComponents {
+		manager, ok := f.(ResourceManager)
+		if !ok {
+			continue
+		}
+		retry := manager.NewResourceManager(nil, nil).TfState().Hints.DeleteRetryOnConflict
+		if retry == nil {
+			continue
+		}
+		resourceManager, err := findResource(t, is.SnakeCaseName(f)).ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		_, ok = resourceManager.TfState().TypeMap[is.TimeoutsField]
+		require.True(t, ok, "%s: no %q attribute", is.SnakeCaseName(f), is.TimeoutsField)
+		for _, ref := range retry.Dependents {
+			dependent, err := findResource(t, ref.Component).ManagerWithSchemaOnly(ctx)
+			require.NoError(t, err)
+			_, ok := dependent.TfState().TypeMap[ref.Field]
+			require.True(t, ok, "%s: dependent %s has no field %q", is.SnakeCaseName(f), ref.Component, ref.Field)
+		}
+	}
+}
+
+func TestErrorHandling_DeleteTimeout(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "view_policy").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	tfState := manager.TfState()
+	retry := tfState.Hints.DeleteRetryOnConflict
+	require.Equal(t, is.DefaultDeleteTimeout, retry.DefaultTimeout())
+
+	timeout, err := tfState.DeleteTimeout()
+	require.NoError(t, err)
+	require.Zero(t, timeout, "not configured")
+
+	tfState.Set("name", "p1")
+	tfState.Set(is.TimeoutsField, map[string]any{"delete": "90s"})
+	timeout, err = tfState.DeleteTimeout()
+	require.NoError(t, err)
+	require.Equal(t, 90*time.Second, timeout)
+	// Terraform only attribute.
+	require.NotContains(t, tfState.GetCreateParams(), is.TimeoutsField)
+}
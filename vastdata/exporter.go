@@ -0,0 +1,568 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements the cluster exporter: it walks every exportable component,
+// lists existing objects through the VAST REST client and renders them as Terraform
+// configuration (resource blocks plus matching `import {}` blocks).
+//
+// The exporter is driven by the same TFStateHints and generated schemas the provider
+// uses at runtime, so the produced configuration only contains user-settable attributes
+// and import IDs follow each resource's ImportFields.
+//
+// See misc/export_hcl.go for the command line wrapper.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"regexp"
+	"slices"
+	"sort"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/schema_generation"
+)
+
+// exportReferenceFields maps attributes holding IDs of other objects to the
+// component (snake case manager name) they point to. When the referenced object is
+// exported as well, the value is rendered as a Terraform reference instead of a literal.
+// ForeignKeyFields hint of the component takes precedence; this map is the fallback
+// for components that do not declare it.
+var exportReferenceFields = map[string]string{
+	"tenant_id":            "tenant",
+	"policy_id":            "view_policy",
+	"qos_policy_id":        "qos_policy",
+	"protection_policy_id": "protection_policy",
+	"s3_policies_ids":      "s3_policy",
+	"user_id":              "user",
+	"volume_id":            "volume",
+}
+
+// exportSkipFields are never rendered into configuration.
+var exportSkipFields = []string{"id", "guid"}
+
+var nonIdentifierChars = regexp.MustCompile(`[^a-z0-9_]+`)
+
+// ExportOptions controls which objects are exported.
+type ExportOptions struct {
+	// Tenants limits the export to objects belonging to the given tenant names.
+	// Objects that carry no tenant information are skipped when the filter is set.
+	Tenants []string
+	// Types limits the export to the given resource types. Both "view" and
+	// "vastdata_view" forms are accepted.
+	Types []string
+}
+
+// ExportResult holds rendered configuration files keyed by file name
+// and non-fatal problems encountered during export.
+type ExportResult struct {
+	Files    map[string]string
+	Warnings []string
+}
+
+// exportLister lists all remote records for the given component.
+type exportLister func(ctx context.Context, manager ResourceManager) (RecordSet, error)
+
+type exportComponent struct {
+	name     string // snake case manager name, e.g. "view_policy"
+	typeName string // terraform resource type, e.g. "vastdata_view_policy"
+	manager  ResourceManager
+	hints    *is.TFStateHints
+	schema   *rschema.Schema
+}
+
+type exportedObject struct {
+	component *exportComponent
+	label     string
+	id        string
+	record    Record
+}
+
+// Exporter turns live cluster objects into Terraform configuration.
+type Exporter struct {
+	opts ExportOptions
+	list exportLister
+}
+
+// NewExporter creates an Exporter that reads cluster objects with the given REST client.
+func NewExporter(rest *VMSRest, opts ExportOptions) *Exporter {
+	return &Exporter{
+		opts: opts,
+		list: func(ctx context.Context, manager ResourceManager) (RecordSet, error) {
+			api := manager.API(rest)
+			if api == nil {
+				return nil, fmt.Errorf("no REST API available")
+			}
+			return api.ListWithContext(ctx, params{})
+		},
+	}
+}
+
+// Export lists objects of all selected components and renders one file per resource type.
+func (e *Exporter) Export(ctx context.Context) (*ExportResult, error) {
+	result := &ExportResult{Files: map[string]string{}}
+
+	components, err := e.components(ctx, result)
+	if err != nil {
+		return nil, err
+	}
+
+	tenantIDs, err := e.tenantIDs(ctx)
+	if err != nil {
+		return nil, err
+	}
+
+	var (
+		labels  = map[string]map[string]bool{}   // typeName -> used labels
+		byID    = map[string]map[string]string{} // component name -> id -> label
+		grouped = map[string][]*exportedObject{} // typeName -> objects
+		order   []string                         // typeName in export order
+	)
+
+	for _, c := range components {
+		records, err := e.list(ctx, c.manager)
+		if err != nil {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: failed to list objects: %v", c.typeName, err))
+			continue
+		}
+		labels[c.typeName] = map[string]bool{}
+		byID[c.name] = map[string]string{}
+		for _, record := range records {
+			if tr, ok := c.manager.(TransformResponseRecord); ok {
+				record = tr.TransformResponseRecord(record)
+			}
+			if !e.matchesTenant(c, record, tenantIDs) {
+				continue
+			}
+			obj := &exportedObject{
+				component: c,
+				record:    record,
+				id:        exportScalarString(record["id"]),
+			}
+			obj.label = uniqueLabel(exportLabel(c, record), obj.id, labels[c.typeName])
+			if obj.id != "" {
+				byID[c.name][obj.id] = obj.label
+			}
+			if _, ok := grouped[c.typeName]; !ok {
+				order = append(order, c.typeName)
+			}
+			grouped[c.typeName] = append(grouped[c.typeName], obj)
+		}
+	}
+
+	for _, typeName := range order {
+		var sb strings.Builder
+		for i, obj := range grouped[typeName] {
+			if i > 0 {
+				sb.WriteString("\n")
+			}
+			block, warnings := renderExportedObject(obj, byID)
+			result.Warnings = append(result.Warnings, warnings...)
+			sb.WriteString(block)
+		}
+		result.Files[typeName+".tf"] = sb.String()
+	}
+	return result, nil
+}
+
+// components returns exportable components matching the type filter.
+// Components with custom schemas or custom read logic cannot be listed generically and are skipped.
+func (e *Exporter) components(ctx context.Context, result *ExportResult) ([]*exportComponent, error) {
+	var components []*exportComponent
+	for _, f := range allTFComponents {
+		rm, ok := f.(ResourceManager)
+		if !ok {
+			continue
+		}
+		name := is.SnakeCaseName(f)
+		typeName := "vastdata_" + name
+		if !e.matchesType(name) {
+			continue
+		}
+		manager := rm.NewResourceManager(nil, nil)
+		hints := manager.TfState().Hints
+		switch {
+		case hints == nil:
+			continue
+		case hints.Importable != nil && !*hints.Importable:
+			continue
+		case hints.TFStateHintsForCustom != nil || hints.SchemaRef == nil || hints.SchemaRef.Read == nil:
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: custom resource, skipped", typeName))
+			continue
+		}
+		if _, ok := manager.(ReadResource); ok {
+			result.Warnings = append(result.Warnings, fmt.Sprintf("%s: custom read logic, skipped", typeName))
+			continue
+		}
+		schema, err := schema_generation.GetCachedResourceSchema(ctx, name, hints)
+		if err != nil {
+			return nil, fmt.Errorf("failed to build schema for %q: %w", typeName, err)
+		}
+		components = append(components, &exportComponent{
+			name:     name,
+			typeName: typeName,
+			manager:  manager,
+			hints:    hints,
+			schema:   schema,
+		})
+	}
+	return components, nil
+}
+
+// tenantIDs resolves tenant names from the tenant filter to their IDs.
+func (e *Exporter) tenantIDs(ctx context.Context) (map[string]bool, error) {
+	if len(e.opts.Tenants) == 0 {
+		return nil, nil
+	}
+	records, err := e.list(ctx, (&Tenant{}).NewResourceManager(nil, nil))
+	if err != nil {
+		return nil, fmt.Errorf("failed to list tenants: %w", err)
+	}
+	ids := map[string]bool{}
+	for _, record := range records {
+		if name, ok := record["name"].(string); ok && slices.Contains(e.opts.Tenants, name) {
+			ids[exportScalarString(record["id"])] = true
+		}
+	}
+	return ids, nil
+}
+
+func (e *Exporter) matchesType(name string) bool {
+	if len(e.opts.Types) == 0 {
+		return true
+	}
+	for _, t := range e.opts.Types {
+		if strings.TrimPrefix(t, "vastdata_") == name {
+			return true
+		}
+	}
+	return false
+}
+
+func (e *Exporter) matchesTenant(c *exportComponent, record Record, tenantIDs map[string]bool) bool {
+	if len(e.opts.Tenants) == 0 {
+		return true
+	}
+	if c.name == "tenant" {
+		name, _ := record["name"].(string)
+		return slices.Contains(e.opts.Tenants, name)
+	}
+	if name, ok := record["tenant_name"].(string); ok && name != "" {
+		return slices.Contains(e.opts.Tenants, name)
+	}
+	if id := exportScalarString(record["tenant_id"]); id != "" {
+		return tenantIDs[id]
+	}
+	return false
+}
+
+// exportLabel builds a resource label from the record name, import fields or ID.
+func exportLabel(c *exportComponent, record Record) string {
+	var base string
+	if name := exportScalarString(record["name"]); name != "" {
+		base = name
+	} else if len(c.hints.ImportFields) > 0 {
+		var parts []string
+		for _, f := range c.hints.ImportFields {
+			if v := exportScalarString(record[f]); v != "" {
+				parts = append(parts, v)
+			}
+		}
+		base = strings.Join(parts, "_")
+	}
+	if base == "" {
+		base = fmt.Sprintf("%s_%s", c.name, exportScalarString(record["id"]))
+	}
+	label := strings.Trim(nonIdentifierChars.ReplaceAllString(strings.ToLower(base), "_"), "_")
+	if label == "" {
+		label = c.name
+	}
+	if label[0] >= '0' && label[0] <= '9' {
+		label = c.name + "_" + label
+	}
+	return label
+}
+
+func uniqueLabel(label, id string, used map[string]bool) string {
+	candidate := label
+	if used[candidate] && id != "" {
+		candidate = fmt.Sprintf("%s_%s", label, id)
+	}
+	for i := 2; used[candidate]; i++ {
+		candidate = fmt.Sprintf("%s_%d", label, i)
+	}
+	used[candidate] = true
+	return candidate
+}
+
+// exportImportID builds the import ID from the resource ImportFields,
+// falling back to the plain record ID.
+func exportImportID(obj *exportedObject) string {
+	fields := obj.component.hints.ImportFields
+	if len(fields) > 0 {
+		var parts []string
+		for _, f := range fields {
+			v := exportScalarString(obj.record[f])
+			if v == "" || strings.ContainsAny(v, ",;=") {
+				parts = nil
+				break
+			}
+			parts = append(parts, fmt.Sprintf("%s=%s", f, v))
+		}
+		if len(parts) > 0 {
+			return strings.Join(parts, ",")
+		}
+	}
+	return obj.id
+}
+
+// renderExportedObject renders resource and import blocks for a single object.
+func renderExportedObject(obj *exportedObject, byID map[string]map[string]string) (string, []string) {
+	var (
+		c        = obj.component
+		hints    = c.hints
+		warnings []string
+		names    []string
+	)
+	for name, a := range c.schema.Attributes {
+		if !a.IsRequired() && !a.IsOptional() {
+			continue
+		}
+		if a.IsSensitive() || a.IsWriteOnly() ||
+			slices.Contains(exportSkipFields, name) ||
+			slices.Contains(hints.ReadOnlyFields, name) {
+			continue
+		}
+		if _, ok := hints.DeleteOnlyBodyFields[name]; ok {
+			continue
+		}
+		if _, ok := hints.DeleteOnlyParamFields[name]; ok {
+			continue
+		}
+		names = append(names, name)
+	}
+	sort.Strings(names)
+
+	var sb strings.Builder
+	fmt.Fprintf(&sb, "resource %q %q {\n", c.typeName, obj.label)
+	for _, name := range names {
+		raw, ok := obj.record[name]
+		if !ok || is.IsNil(raw) {
+			continue
+		}
+		if target, ok := exportReferenceTarget(hints, name); ok && target != c.name {
+			if ref, ok := renderReference(raw, target, byID); ok {
+				fmt.Fprintf(&sb, "  %s = %s\n", name, ref)
+				continue
+			}
+		}
+		val, err := is.BuildAttrValueFromAny(c.schema.Attributes[name].GetType(), raw)
+		if err != nil {
+			warnings = append(warnings, fmt.Sprintf("%s.%s: skipping attribute %q: %v", c.typeName, obj.label, name, err))
+			continue
+		}
+		if val.IsNull() || val.IsUnknown() {
+			continue
+		}
+		fmt.Fprintf(&sb, "  %s = %s\n", name, renderHCLValue(val, 1))
+	}
+	sb.WriteString("}\n\n")
+
+	fmt.Fprintf(&sb, "import {\n  to = %s.%s\n  id = %s\n}\n", c.typeName, obj.label, hclQuote(exportImportID(obj)))
+	return sb.String(), warnings
+}
+
+// exportReferenceTarget returns component referenced by the given attribute.
+func exportReferenceTarget(hints *is.TFStateHints, name string) (string, bool) {
+	if target, ok := hints.ForeignKeyFields[name]; ok {
+		return target, true
+	}
+	target, ok := exportReferenceFields[name]
+	return target, ok
+}
+
+// renderReference renders ID values (scalar or list) as references to exported objects.
+// Returns false if any of the referenced objects has not been exported.
+func renderReference(raw any, target string, byID map[string]map[string]string) (string, bool) {
+	ids, ok := byID[target]
+	if !ok {
+		return "", false
+	}
+	refOf := func(v any) (string, bool) {
+		label, ok := ids[exportScalarString(v)]
+		if !ok {
+			return "", false
+		}
+		return fmt.Sprintf("vastdata_%s.%s.id", target, label), true
+	}
+	if list, ok := raw.([]any); ok {
+		refs := make([]string, 0, len(list))
+		for _, item := range list {
+			ref, ok := refOf(item)
+			if !ok {
+				return "", false
+			}
+			refs = append(refs, ref)
+		}
+		return "[" + strings.Join(refs, ", ") + "]", true
+	}
+	return refOf(raw)
+}
+
+// renderHCLValue renders a framework value as an HCL expression.
+func renderHCLValue(v attr.Value, depth int) string {
+	indent := strings.Repeat("  ", depth)
+	switch val := v.(type) {
+	case types.String:
+		return hclQuote(val.ValueString())
+	case types.Int64:
+		return strconv.FormatInt(val.ValueInt64(), 10)
+	case types.Float64:
+		return strconv.FormatFloat(val.ValueFloat64(), 'f', -1, 64)
+	case types.Number:
+		return val.ValueBigFloat().Text('f', -1)
+	case types.Bool:
+		return strconv.FormatBool(val.ValueBool())
+	case types.List:
+		return renderHCLList(val.Elements(), depth, false)
+	case types.Set:
+		return renderHCLList(val.Elements(), depth, true)
+	case types.Map:
+		return renderHCLObject(val.Elements(), indent)
+	case types.Object:
+		return renderHCLObject(val.Attributes(), indent)
+	case is.OneOfValue:
+		return renderHCLObject(val.Attributes(), indent)
+	case is.RenamedObjectValue:
+		return renderHCLObject(val.Attributes(), indent)
+	case is.JSONStringValue:
+		return renderHCLJSON(val.ValueString())
+	case basetypes.StringValuable:
+		str, _ := val.ToStringValue(context.Background())
+		return hclQuote(str.ValueString())
+	case basetypes.ListValuable:
+		list, _ := val.ToListValue(context.Background())
+		return renderHCLList(list.Elements(), depth, false)
+	default:
+		return hclQuote(v.String())
+	}
+}
+
+func renderHCLList(elems []attr.Value, depth int, sorted bool) string {
+	items := make([]string, 0, len(elems))
+	for _, e := range elems {
+		if e.IsNull() || e.IsUnknown() {
+			continue
+		}
+		items = append(items, renderHCLValue(e, depth+1))
+	}
+	if sorted {
+		// Sets have no order; sort for deterministic output.
+		sort.Strings(items)
+	}
+	if len(items) == 0 {
+		return "[]"
+	}
+	multiline := false
+	for _, item := range items {
+		if strings.Contains(item, "\n") {
+			multiline = true
+			break
+		}
+	}
+	if !multiline {
+		return "[" + strings.Join(items, ", ") + "]"
+	}
+	indent := strings.Repeat("  ", depth)
+	return "[\n" + indent + "  " + strings.Join(items, ",\n"+indent+"  ") + ",\n" + indent + "]"
+}
+
+func renderHCLObject(fields map[string]attr.Value, indent string) string {
+	keys := make([]string, 0, len(fields))
+	for k, v := range fields {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		keys = append(keys, k)
+	}
+	if len(keys) == 0 {
+		return "{}"
+	}
+	sort.Strings(keys)
+	var sb strings.Builder
+	sb.WriteString("{\n")
+	for _, k := range keys {
+		key := k
+		if nonIdentifierChars.MatchString(k) || k[0] >= '0' && k[0] <= '9' {
+			key = hclQuote(k)
+		}
+		fmt.Fprintf(&sb, "%s  %s = %s\n", indent, key, renderHCLValue(fields[k], len(indent)/2+1))
+	}
+	sb.WriteString(indent + "}")
+	return sb.String()
+}
+
+// renderHCLJSON renders JSON string as jsonencode(...) call, so that exported configuration stays readable.
+// JSON is a valid HCL expression, only template sequences in strings must be escaped.
+func renderHCLJSON(s string) string {
+	var decoded any
+	if err := json.Unmarshal([]byte(s), &decoded); err != nil {
+		return hclQuote(s)
+	}
+	encoded, err := json.Marshal(decoded)
+	if err != nil {
+		return hclQuote(s)
+	}
+	out := strings.ReplaceAll(string(encoded), "${", "$${")
+	return "jsonencode(" + strings.ReplaceAll(out, "%{", "%%{") + ")"
+}
+
+// hclQuote renders s as an HCL string literal, escaping template sequences.
+func hclQuote(s string) string {
+	var sb strings.Builder
+	sb.WriteByte('"')
+	for _, r := range s {
+		switch r {
+		case '"':
+			sb.WriteString(`\"`)
+		case '\\':
+			sb.WriteString(`\\`)
+		case '\n':
+			sb.WriteString(`\n`)
+		case '\r':
+			sb.WriteString(`\r`)
+		case '\t':
+			sb.WriteString(`\t`)
+		default:
+			if r < 0x20 {
+				fmt.Fprintf(&sb, `\u%04x`, r)
+			} else {
+				sb.WriteRune(r)
+			}
+		}
+	}
+	sb.WriteByte('"')
+	out := strings.ReplaceAll(sb.String(), "${", "$${")
+	return strings.ReplaceAll(out, "%{", "%%{")
+}
+
+// exportScalarString renders scalar record values (IDs, names) as strings.
+func exportScalarString(v any) string {
+	switch val := v.(type) {
+	case nil:
+		return ""
+	case string:
+		return val
+	case float64:
+		return strconv.FormatFloat(val, 'f', -1, 64)
+	default:
+		return fmt.Sprintf("%v", val)
+	}
+}
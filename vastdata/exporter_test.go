@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// fakeVMS serves recorded list responses keyed by component name.
+type fakeVMS map[string]RecordSet
+
+func (f fakeVMS) list(_ context.Context, manager ResourceManager) (RecordSet, error) {
+	records, ok := f[is.SnakeCaseName(manager)]
+	if !ok {
+		return nil, fmt.Errorf("not found")
+	}
+	return records, nil
+}
+
+func newTestExporter(vms fakeVMS, opts ExportOptions) *Exporter {
+	return &Exporter{opts: opts, list: vms.list}
+}
+
+func testVMS() fakeVMS {
+	return fakeVMS{
+		"tenant": {
+			{"id": float64(1), "name": "default"},
+			{"id": float64(2), "name": "team-a"},
+		},
+		"view_policy": {
+			{"id": float64(10), "name": "default", "tenant_id": float64(1), "tenant_name": "default"},
+			{"id": float64(11), "name": "shared", "tenant_id": float64(2), "tenant_name": "team-a"},
+			{"id": float64(12), "name": "shared", "tenant_id": float64(1), "tenant_name": "default"},
+		},
+		"view": {
+			{"id": float64(100), "path": "/data", "policy_id": float64(11), "tenant_id": float64(2), "tenant_name": "team-a", "protocols": []any{"NFS", "SMB"}},
+		},
+	}
+}
+
+func TestExporter_ReferencesAndImports(t *testing.T) {
+	exporter := newTestExporter(testVMS(), ExportOptions{Types: []string{"tenant", "vastdata_view_policy", "view"}})
+	result, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+
+	require.Contains(t, result.Files, "vastdata_tenant.tf")
+	require.Contains(t, result.Files, "vastdata_view_policy.tf")
+	require.Contains(t, result.Files, "vastdata_view.tf")
+
+	tenants := result.Files["vastdata_tenant.tf"]
+	assert.Contains(t, tenants, `resource "vastdata_tenant" "team_a" {`)
+	assert.Contains(t, tenants, "to = vastdata_tenant.team_a\n  id = \"2\"")
+
+	policies := result.Files["vastdata_view_policy.tf"]
+	assert.Contains(t, policies, `resource "vastdata_view_policy" "default" {`)
+	assert.Contains(t, policies, `resource "vastdata_view_policy" "shared" {`)
+	// Same name in two tenants must produce distinct labels.
+	assert.Contains(t, policies, `resource "vastdata_view_policy" "shared_12" {`)
+	assert.Contains(t, policies, "tenant_id = vastdata_tenant.team_a.id")
+	assert.Contains(t, policies, `id = "name=shared,tenant_name=team-a"`)
+
+	views := result.Files["vastdata_view.tf"]
+	assert.Contains(t, views, "policy_id = vastdata_view_policy.shared.id")
+	assert.Contains(t, views, "tenant_id = vastdata_tenant.team_a.id")
+	assert.Contains(t, views, `path = "/data"`)
+	assert.NotContains(t, views, "  id = 100")
+}
+
+func TestExporter_TenantFilter(t *testing.T) {
+	exporter := newTestExporter(testVMS(), ExportOptions{
+		Tenants: []string{"team-a"},
+		Types:   []string{"tenant", "view_policy"},
+	})
+	result, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+
+	assert.NotContains(t, result.Files["vastdata_tenant.tf"], `"default"`)
+	assert.Contains(t, result.Files["vastdata_view_policy.tf"], `"shared"`)
+	assert.NotContains(t, result.Files["vastdata_view_policy.tf"], `resource "vastdata_view_policy" "default"`)
+}
+
+func TestExporter_UnresolvedReferenceIsLiteral(t *testing.T) {
+	exporter := newTestExporter(testVMS(), ExportOptions{Types: []string{"view_policy"}})
+	result, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Contains(t, result.Files["vastdata_view_policy.tf"], "tenant_id = 2")
+}
+
+func TestExporter_ListFailureIsWarning(t *testing.T) {
+	exporter := newTestExporter(fakeVMS{}, ExportOptions{Types: []string{"quota"}})
+	result, err := exporter.Export(context.Background())
+	require.NoError(t, err)
+	assert.Empty(t, result.Files)
+	require.Len(t, result.Warnings, 1)
+	assert.Contains(t, result.Warnings[0], "vastdata_quota")
+}
+
+func TestExporter_HCLQuote(t *testing.T) {
+	assert.Equal(t, `"a\"b\\c\n"`, hclQuote("a\"b\\c\n"))
+	assert.Equal(t, `"$${var} %%{if}"`, hclQuote("${var} %{if}"))
+}
+
+func TestExporter_RenderHCLValue(t *testing.T) {
+	set := types.SetValueMust(types.StringType, []attr.Value{types.StringValue("b"), types.StringValue("a")})
+	assert.Equal(t, `["a", "b"]`, renderHCLValue(set, 1))
+
+	obj := types.ObjectValueMust(
+		map[string]attr.Type{"x": types.Int64Type, "y": types.StringType},
+		map[string]attr.Value{"x": types.Int64Value(1), "y": types.StringNull()},
+	)
+	assert.Equal(t, "{\n    x = 1\n  }", renderHCLValue(obj, 1))
+
+	assert.Equal(t, `jsonencode({"a":"$${b}","c":[1]})`, renderHCLValue(is.NewJSONStringValue(`{"c": [1], "a": "${b}"}`), 1))
+}
@@ -28,7 +28,8 @@ func (m *FolderReadOnly) NewResourceManager(raw map[string]attr.Value, schema an
 		raw,
 		schema,
 		&is.TFStateHints{
-			Importable: &notImportable,
+			Importable:     &notImportable,
+			IdentityFields: []string{"path", "tenant_id"},
 			TFStateHintsForCustom: &is.TFStateHintsForCustom{
 				Description: "Make a Folder Read-Only",
 				SchemaAttributes: map[string]any{
@@ -26,6 +26,7 @@ func (m *GlobalLocalSnapshot) NewResourceManager(raw map[string]attr.Value, sche
 		&is.TFStateHints{
 			SchemaRef:            GlobalLocalSnapshotSchemaRef,
 			RequiredSchemaFields: []string{"name", "loanee_root_path", "loanee_tenant_id", "loanee_snapshot_id"},
+			VolatileFields:       []string{"bw", "eta", "sync_progress"},
 		},
 	)}
 }
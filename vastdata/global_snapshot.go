@@ -23,7 +23,8 @@ func (m *GlobalSnapshot) NewResourceManager(raw map[string]attr.Value, schema an
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: GlobalSnapshotSchemaRef,
+			SchemaRef:      GlobalSnapshotSchemaRef,
+			VolatileFields: []string{"bw", "eta", "sync_progress"},
 		},
 	)}
 }
@@ -0,0 +1,79 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// apiObjectType is implemented by custom object types whose API representation differs
+// from Terraform representation (see OneOfType and RenamedObjectType).
+// BuildAttrValueFromAny builds their values from API objects via fromRaw.
+type apiObjectType interface {
+	basetypes.ObjectTypable
+	attr.TypeWithAttributeTypes
+	nullValue() attr.Value
+	wrap(obj basetypes.ObjectValue) attr.Value
+	fromRaw(raw map[string]any) (attr.Value, error)
+}
+
+// apiObjectValue is the value of apiObjectType.
+// ConvertAttrValueToRaw converts it into API object via toRaw.
+type apiObjectValue interface {
+	basetypes.ObjectValuable
+	Attributes() map[string]attr.Value
+	toRaw() any
+}
+
+// apiStringType is implemented by custom string types whose API representation differs
+// from Terraform representation (see JSONStringType and CapacityType).
+// BuildAttrValueFromAny builds their values from API values via fromRaw.
+type apiStringType interface {
+	basetypes.StringTypable
+	nullValue() attr.Value
+	fromRaw(raw any) (attr.Value, error)
+}
+
+// apiStringValue is the value of apiStringType.
+// ConvertAttrValueToRaw converts it into API value via toRaw.
+type apiStringValue interface {
+	basetypes.StringValuable
+	toRaw() any
+}
+
+// apiListType is implemented by custom list types with semantic equality (see IPRangeListType).
+// Values are built and converted as regular lists of listType() and then wrapped.
+type apiListType interface {
+	basetypes.ListTypable
+	listType() types.ListType
+	wrap(list basetypes.ListValue) attr.Value
+}
+
+// apiListValue is the value of apiListType.
+// ConvertAttrValueToRaw converts it into API value via toRaw.
+type apiListValue interface {
+	basetypes.ListValuable
+	toRaw() any
+}
+
+// apiAttributeNames returns set of API property names of object type.
+func apiAttributeNames(t attr.Type) map[string]bool {
+	objType, ok := t.(attr.TypeWithAttributeTypes)
+	if !ok {
+		return nil
+	}
+	var apiNames map[string]string
+	if renamed, ok := t.(RenamedObjectType); ok {
+		apiNames = renamed.APINames
+	}
+	names := make(map[string]bool, len(objType.AttributeTypes()))
+	for name := range objType.AttributeTypes() {
+		if apiName, ok := apiNames[name]; ok {
+			name = apiName
+		}
+		names[name] = true
+	}
+	return names
+}
@@ -0,0 +1,211 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"fmt"
+	"math/big"
+	"regexp"
+	"strconv"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ apiStringType                              = CapacityType{}
+	_ apiStringValue                             = CapacityValue{}
+	_ basetypes.StringValuableWithSemanticEquals = CapacityValue{}
+	_ xattr.ValidateableAttribute                = CapacityValue{}
+)
+
+// capacityUnits maps upper cased SI and IEC unit symbols to their size in bytes.
+var capacityUnits = map[string]int64{
+	"":    1,
+	"B":   1,
+	"KB":  1e3,
+	"MB":  1e6,
+	"GB":  1e9,
+	"TB":  1e12,
+	"PB":  1e15,
+	"EB":  1e18,
+	"KIB": 1 << 10,
+	"MIB": 1 << 20,
+	"GIB": 1 << 30,
+	"TIB": 1 << 40,
+	"PIB": 1 << 50,
+	"EIB": 1 << 60,
+}
+
+var capacityRegex = regexp.MustCompile(`^\s*([+-]?[0-9]+(?:\.[0-9]+)?)\s*([A-Za-z]*)\s*$`)
+
+// ParseCapacity converts capacity like "1099511627776", "500GB", "10TiB" or "1.5PB" into bytes.
+// Leading sign is accepted, as API uses negative sentinels (eg -1 for unlimited).
+func ParseCapacity(s string) (int64, error) {
+	m := capacityRegex.FindStringSubmatch(s)
+	if m == nil {
+		return 0, fmt.Errorf("invalid capacity %q: expected number optionally followed by unit (e.g. 500GB, 10TiB)", s)
+	}
+	unit, ok := capacityUnits[strings.ToUpper(m[2])]
+	if !ok {
+		return 0, fmt.Errorf("invalid capacity %q: unknown unit %q", s, m[2])
+	}
+	amount, _ := new(big.Rat).SetString(m[1])
+	amount.Mul(amount, new(big.Rat).SetInt64(unit))
+	if !amount.IsInt() || !amount.Num().IsInt64() {
+		return 0, fmt.Errorf("invalid capacity %q: must be whole number of bytes within %d", s, int64(1<<63-1))
+	}
+	return amount.Num().Int64(), nil
+}
+
+// CapacityType is the type of size attribute (capacity, bandwidth) which accepts human readable units.
+// In Terraform value is a string: plain number of bytes ("1099511627776") or number with SI/IEC unit
+// ("500GB", "10TiB", "1.5PB"). Numbers in configuration are converted to strings by Terraform.
+// API expects and returns integer amount of Unit.
+type CapacityType struct {
+	basetypes.StringType
+	// Unit is the unit of API value ("B" for bytes, "MB" for megabytes, ...).
+	Unit string
+}
+
+// NewCapacityType creates capacity type for API unit. Empty unit means bytes.
+func NewCapacityType(unit string) CapacityType {
+	if unit == "" {
+		unit = "B"
+	}
+	return CapacityType{Unit: unit}
+}
+
+func (t CapacityType) Equal(o attr.Type) bool {
+	other, ok := o.(CapacityType)
+	if !ok {
+		return false
+	}
+	return t.Unit == other.Unit
+}
+
+func (t CapacityType) String() string {
+	return fmt.Sprintf("CapacityType[%s]", t.Unit)
+}
+
+func (t CapacityType) ValueType(_ context.Context) attr.Value {
+	return CapacityValue{typ: t}
+}
+
+func (t CapacityType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return CapacityValue{StringValue: in, typ: t}, nil
+}
+
+func (t CapacityType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return CapacityValue{StringValue: val.(basetypes.StringValue), typ: t}, nil
+}
+
+func (t CapacityType) nullValue() attr.Value {
+	return CapacityValue{StringValue: basetypes.NewStringNull(), typ: t}
+}
+
+// fromRaw builds value from API amount of Unit. Strings with units (eg from raw state) are kept as is.
+func (t CapacityType) fromRaw(raw any) (attr.Value, error) {
+	if s, ok := raw.(string); ok {
+		if _, err := ParseCapacity(s); err != nil {
+			return nil, err
+		}
+		return NewCapacityValue(t, s), nil
+	}
+	n, err := ToInt(raw)
+	if err != nil {
+		return nil, err
+	}
+	return NewCapacityValue(t, strconv.FormatInt(n*t.unitSize(), 10)), nil
+}
+
+func (t CapacityType) unitSize() int64 {
+	if size, ok := capacityUnits[strings.ToUpper(t.Unit)]; ok {
+		return size
+	}
+	return 1
+}
+
+// CapacityValue is the value of CapacityType.
+// Values which denote the same number of bytes (eg "1TiB" and "1099511627776") are semantically equal.
+type CapacityValue struct {
+	basetypes.StringValue
+	typ CapacityType
+}
+
+// NewCapacityValue creates known capacity value.
+func NewCapacityValue(t CapacityType, s string) CapacityValue {
+	return CapacityValue{StringValue: basetypes.NewStringValue(s), typ: t}
+}
+
+func (v CapacityValue) Type(_ context.Context) attr.Type {
+	return v.typ
+}
+
+func (v CapacityValue) Equal(o attr.Value) bool {
+	other, ok := o.(CapacityValue)
+	if !ok {
+		return false
+	}
+	return v.typ.Equal(other.typ) && v.StringValue.Equal(other.StringValue)
+}
+
+func (v CapacityValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(CapacityValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	a, errA := ParseCapacity(v.ValueString())
+	b, errB := ParseCapacity(newValue.ValueString())
+	return errA == nil && errB == nil && a == b, nil
+}
+
+func (v CapacityValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	bytes, err := ParseCapacity(v.ValueString())
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(req.Path, "Invalid Capacity Value", err.Error())
+		return
+	}
+	if bytes%v.typ.unitSize() != 0 {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Capacity Value",
+			fmt.Sprintf("Capacity %q must be a whole number of %s.", v.ValueString(), v.typ.Unit),
+		)
+	}
+}
+
+// APIAmount returns value converted into amount of API Unit (truncated if not a whole number of Unit).
+func (v CapacityValue) APIAmount() (int64, error) {
+	bytes, err := ParseCapacity(v.ValueString())
+	if err != nil {
+		return 0, err
+	}
+	return bytes / v.typ.unitSize(), nil
+}
+
+// toRaw converts value into API amount of Unit. Invalid values (rejected by ValidateAttribute) are sent as is.
+func (v CapacityValue) toRaw() any {
+	amount, err := v.APIAmount()
+	if err != nil {
+		return v.ValueString()
+	}
+	return amount
+}
@@ -0,0 +1,94 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/stretchr/testify/require"
+)
+
+func TestCapacity_Parse(t *testing.T) {
+	for in, expected := range map[string]int64{
+		"1099511627776": 1 << 40,
+		"1TiB":          1 << 40,
+		"10 tib":        10 << 40,
+		"500GB":         500e9,
+		"1.5PB":         15e14,
+		"0.5KiB":        512,
+		"7B":            7,
+		"-1":            -1,
+		"-1GB":          -1e9,
+		"+2KiB":         2048,
+	} {
+		bytes, err := ParseCapacity(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expected, bytes, in)
+	}
+	for _, in := range []string{"", "TiB", "--1", "- 1", "1.5", "10XB", "1e3", "20EiB"} {
+		_, err := ParseCapacity(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestCapacity_RoundTrip(t *testing.T) {
+	bytesType := NewCapacityType("")
+
+	val, err := BuildAttrValueFromAny(bytesType, float64(1<<40))
+	require.NoError(t, err)
+	require.Equal(t, NewCapacityValue(bytesType, "1099511627776"), val)
+	require.Equal(t, int64(1<<40), ConvertAttrValueToRaw(val, bytesType))
+	require.Equal(t, int64(10<<40), ConvertAttrValueToRaw(NewCapacityValue(bytesType, "10TiB"), bytesType))
+
+	// API value in MB.
+	mbType := NewCapacityType("MB")
+	val, err = BuildAttrValueFromAny(mbType, 1500)
+	require.NoError(t, err)
+	require.Equal(t, NewCapacityValue(mbType, "1500000000"), val)
+	require.Equal(t, int64(1500), ConvertAttrValueToRaw(NewCapacityValue(mbType, "1.5GB"), mbType))
+
+	// Negative sentinel (eg -1 for unlimited) round-trips as number.
+	val, err = BuildAttrValueFromAny(mbType, -1)
+	require.NoError(t, err)
+	require.Equal(t, NewCapacityValue(mbType, "-1000000"), val)
+	require.Equal(t, int64(-1), ConvertAttrValueToRaw(val, mbType))
+
+	val, err = BuildAttrValueFromAny(mbType, nil)
+	require.NoError(t, err)
+	require.True(t, val.IsNull())
+	require.False(t, bytesType.Equal(mbType))
+}
+
+func TestCapacity_SemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	typ := NewCapacityType("B")
+
+	equal, diags := NewCapacityValue(typ, "1TiB").StringSemanticEquals(ctx, NewCapacityValue(typ, "1099511627776"))
+	require.False(t, diags.HasError())
+	require.True(t, equal)
+
+	equal, _ = NewCapacityValue(typ, "1TiB").StringSemanticEquals(ctx, NewCapacityValue(typ, "1TB"))
+	require.False(t, equal)
+
+	equal, _ = NewCapacityValue(typ, "-1").StringSemanticEquals(ctx, NewCapacityValue(typ, "-1B"))
+	require.True(t, equal)
+}
+
+func TestCapacity_Validate(t *testing.T) {
+	req := xattr.ValidateAttributeRequest{Path: path.Root("size")}
+	validate := func(v CapacityValue) bool {
+		resp := &xattr.ValidateAttributeResponse{}
+		v.ValidateAttribute(context.Background(), req, resp)
+		return !resp.Diagnostics.HasError()
+	}
+
+	require.True(t, validate(NewCapacityValue(NewCapacityType("B"), "10TiB")))
+	require.False(t, validate(NewCapacityValue(NewCapacityType("B"), "10 terabytes")))
+	require.True(t, validate(NewCapacityValue(NewCapacityType("MB"), "2GB")))
+	// Not a whole number of MB.
+	require.False(t, validate(NewCapacityValue(NewCapacityType("MB"), "1MiB")))
+	require.True(t, validate(NewCapacityValue(NewCapacityType("B"), "-1")))
+}
@@ -3,11 +3,14 @@
 package internalstate
 
 import (
+	"context"
 	"fmt"
 	"math/big"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
@@ -70,6 +73,16 @@ func FillFrameworkValues(val tftypes.Value, schema any) (map[string]attr.Value,
 		for k, v := range s.Attributes {
 			attrTypes[k] = v.GetType()
 		}
+	case identityschema.Schema:
+		attrTypes = make(map[string]attr.Type, len(s.Attributes))
+		for k, v := range s.Attributes {
+			attrTypes[k] = v.GetType()
+		}
+	case eschema.Schema:
+		attrTypes = make(map[string]attr.Type, len(s.Attributes))
+		for k, v := range s.Attributes {
+			attrTypes[k] = v.GetType()
+		}
 	default:
 		return nil, fmt.Errorf("unsupported schema type: %T", schema)
 	}
@@ -118,6 +131,12 @@ func BuildAttrValueFromAny(t attr.Type, val any) (attr.Value, error) {
 				return types.MapNull(tt.ElemType), nil
 			case types.ObjectType:
 				return types.ObjectNull(tt.AttributeTypes()), nil
+			case apiObjectType:
+				return tt.nullValue(), nil
+			case apiStringType:
+				return tt.nullValue(), nil
+			case apiListType:
+				return tt.wrap(types.ListNull(tt.listType().ElemType)), nil
 			default:
 				return nil, fmt.Errorf("unsupported null type: %T", t)
 			}
@@ -216,6 +235,23 @@ func BuildAttrValueFromAny(t attr.Type, val any) (attr.Value, error) {
 			return nil, fmt.Errorf("objectValue: %s", diags)
 		}
 		return obj, nil
+
+	case apiObjectType:
+		rawObj, ok := val.(map[string]any)
+		if !ok {
+			return nil, fmt.Errorf("expected map[string]any for object, got %T", val)
+		}
+		return tt.fromRaw(rawObj)
+
+	case apiStringType:
+		return tt.fromRaw(val)
+
+	case apiListType:
+		list, err := BuildAttrValueFromAny(tt.listType(), val)
+		if err != nil {
+			return nil, err
+		}
+		return tt.wrap(list.(types.List)), nil
 	}
 
 	return nil, fmt.Errorf("unsupported type: %T", t)
@@ -249,6 +285,12 @@ func tfTypeToAttrType(t attr.Type, val tftypes.Value) (attr.Value, error) {
 					return nil, fmt.Errorf("expected ObjectType, got %T", t)
 				}
 				return types.ObjectNull(ot.AttributeTypes()), nil
+			case apiObjectType:
+				return tt.nullValue(), nil
+			case apiStringType:
+				return tt.nullValue(), nil
+			case apiListType:
+				return tt.wrap(types.ListNull(tt.listType().ElemType)), nil
 			default:
 				return nil, fmt.Errorf("unsupported null type: %T", t)
 			}
@@ -385,6 +427,19 @@ func tfTypeToAttrType(t attr.Type, val tftypes.Value) (attr.Value, error) {
 		}
 		return obj, nil
 
+	case apiObjectType:
+		obj, err := tfTypeToAttrType(types.ObjectType{AttrTypes: tt.AttributeTypes()}, val)
+		if err != nil {
+			return nil, err
+		}
+		return tt.wrap(obj.(types.Object)), nil
+
+	case apiStringType:
+		return tt.ValueFromTerraform(context.Background(), val)
+
+	case apiListType:
+		return tt.ValueFromTerraform(context.Background(), val)
+
 	default:
 		return nil, fmt.Errorf("unsupported type: %T", t)
 	}
@@ -396,6 +451,10 @@ func ConvertAttrValueToRaw(val attr.Value, t attr.Type) any {
 	}
 
 	switch v := val.(type) {
+	case apiStringValue:
+		return v.toRaw()
+	case apiListValue:
+		return v.toRaw()
 	case types.String:
 		return v.ValueString()
 	case types.Int64:
@@ -451,6 +510,9 @@ func ConvertAttrValueToRaw(val attr.Value, t attr.Type) any {
 		}
 		return out
 
+	case apiObjectValue:
+		return v.toRaw()
+
 	case types.Object:
 		objType := t.(types.ObjectType)
 		attrs := v.Attributes()
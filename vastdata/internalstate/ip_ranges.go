@@ -0,0 +1,126 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ apiListType                              = IPRangeListType{}
+	_ apiListValue                             = IPRangeListValue{}
+	_ basetypes.ListValuableWithSemanticEquals = IPRangeListValue{}
+)
+
+// IPRangeListType is the type of list of IP ranges, each range being [start, end] pair of addresses
+// (eg vip pool "ip_ranges"). VMS returns ranges in its own order, so lists holding the same ranges
+// are semantically equal regardless of order. Single address range may be written as [ip] or [ip, ip].
+type IPRangeListType struct {
+	basetypes.ListType
+}
+
+// NewIPRangeListType creates IP range list type.
+func NewIPRangeListType() IPRangeListType {
+	return IPRangeListType{ListType: basetypes.ListType{ElemType: types.ListType{ElemType: types.StringType}}}
+}
+
+func (t IPRangeListType) Equal(o attr.Type) bool {
+	_, ok := o.(IPRangeListType)
+	return ok
+}
+
+func (t IPRangeListType) String() string {
+	return "IPRangeListType"
+}
+
+func (t IPRangeListType) ValueType(_ context.Context) attr.Value {
+	return IPRangeListValue{}
+}
+
+func (t IPRangeListType) ValueFromList(_ context.Context, in basetypes.ListValue) (basetypes.ListValuable, diag.Diagnostics) {
+	return IPRangeListValue{ListValue: in}, nil
+}
+
+func (t IPRangeListType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.ListType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return IPRangeListValue{ListValue: val.(basetypes.ListValue)}, nil
+}
+
+func (t IPRangeListType) listType() types.ListType {
+	return t.ListType
+}
+
+func (t IPRangeListType) wrap(list basetypes.ListValue) attr.Value {
+	return IPRangeListValue{ListValue: list}
+}
+
+// IPRangeListValue is the value of IPRangeListType.
+type IPRangeListValue struct {
+	basetypes.ListValue
+}
+
+func (v IPRangeListValue) Type(_ context.Context) attr.Type {
+	return NewIPRangeListType()
+}
+
+func (v IPRangeListValue) Equal(o attr.Value) bool {
+	other, ok := o.(IPRangeListValue)
+	if !ok {
+		return false
+	}
+	return v.ListValue.Equal(other.ListValue)
+}
+
+func (v IPRangeListValue) ListSemanticEquals(_ context.Context, newValuable basetypes.ListValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(IPRangeListValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	return slices.Equal(v.normalizedRanges(), newValue.normalizedRanges()), nil
+}
+
+// normalizedRanges returns sorted "start-end" representation of ranges.
+func (v IPRangeListValue) normalizedRanges() []string {
+	var ranges []string
+	for _, elem := range v.Elements() {
+		list, ok := elem.(basetypes.ListValue)
+		if !ok {
+			continue
+		}
+		var bounds []string
+		for _, b := range list.Elements() {
+			if s, ok := b.(basetypes.StringValue); ok {
+				bounds = append(bounds, s.ValueString())
+			}
+		}
+		switch len(bounds) {
+		case 0:
+			continue
+		case 1:
+			bounds = append(bounds, bounds[0])
+		}
+		ranges = append(ranges, fmt.Sprintf("%s-%s", bounds[0], bounds[len(bounds)-1]))
+	}
+	slices.Sort(ranges)
+	return ranges
+}
+
+func (v IPRangeListValue) toRaw() any {
+	return ConvertAttrValueToRaw(v.ListValue, NewIPRangeListType().ListType)
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestIPRanges_SemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	typ := NewIPRangeListType()
+	build := func(raw []any) IPRangeListValue {
+		val, err := BuildAttrValueFromAny(typ, raw)
+		require.NoError(t, err)
+		return val.(IPRangeListValue)
+	}
+
+	configured := build([]any{
+		[]any{"10.0.0.1", "10.0.0.9"},
+		[]any{"10.0.1.1", "10.0.1.1"},
+	})
+	returned := build([]any{
+		[]any{"10.0.1.1"},
+		[]any{"10.0.0.1", "10.0.0.9"},
+	})
+	equal, diags := configured.ListSemanticEquals(ctx, returned)
+	require.False(t, diags.HasError())
+	require.True(t, equal)
+
+	// Bounds are not interchangeable.
+	equal, _ = configured.ListSemanticEquals(ctx, build([]any{
+		[]any{"10.0.0.9", "10.0.0.1"},
+		[]any{"10.0.1.1", "10.0.1.1"},
+	}))
+	require.False(t, equal)
+
+	equal, _ = configured.ListSemanticEquals(ctx, build([]any{[]any{"10.0.0.1", "10.0.0.9"}}))
+	require.False(t, equal)
+}
+
+func TestIPRanges_RoundTrip(t *testing.T) {
+	typ := NewIPRangeListType()
+	raw := []any{[]any{"10.0.0.1", "10.0.0.9"}}
+
+	val, err := BuildAttrValueFromAny(typ, raw)
+	require.NoError(t, err)
+	require.Equal(t, typ, val.Type(context.Background()))
+	require.Equal(t, raw, ConvertAttrValueToRaw(val, typ))
+
+	val, err = BuildAttrValueFromAny(typ, nil)
+	require.NoError(t, err)
+	require.True(t, val.IsNull())
+	require.IsType(t, IPRangeListValue{}, val)
+}
@@ -0,0 +1,177 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"reflect"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ apiStringType                              = JSONStringType{}
+	_ apiStringValue                             = JSONStringValue{}
+	_ basetypes.StringValuableWithSemanticEquals = JSONStringValue{}
+	_ xattr.ValidateableAttribute                = JSONStringValue{}
+)
+
+// JSONStringType is the type of free-form object attribute (object schema without properties).
+// In Terraform such value is a JSON encoded string (usually built with jsonencode),
+// while API expects and returns decoded object. ConvertAttrValueToRaw and BuildAttrValueFromAny
+// translate between these two shapes.
+type JSONStringType struct {
+	basetypes.StringType
+}
+
+func (t JSONStringType) Equal(o attr.Type) bool {
+	_, ok := o.(JSONStringType)
+	return ok
+}
+
+func (t JSONStringType) String() string {
+	return "JSONStringType"
+}
+
+func (t JSONStringType) ValueType(_ context.Context) attr.Value {
+	return JSONStringValue{}
+}
+
+func (t JSONStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return JSONStringValue{StringValue: in}, nil
+}
+
+func (t JSONStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return JSONStringValue{StringValue: val.(basetypes.StringValue)}, nil
+}
+
+func (t JSONStringType) nullValue() attr.Value {
+	return JSONStringValue{StringValue: basetypes.NewStringNull()}
+}
+
+// fromRaw encodes API value as JSON. API strings are encoded as well ("123" becomes `"123"`):
+// values which are already encoded (stored state) are converted by stateValue instead.
+func (t JSONStringType) fromRaw(raw any) (attr.Value, error) {
+	b, err := json.Marshal(raw)
+	if err != nil {
+		return nil, fmt.Errorf("cannot encode %T as JSON: %w", raw, err)
+	}
+	return NewJSONStringValue(string(b)), nil
+}
+
+// JSONStringValue is the value of JSONStringType.
+// Values which differ only in key order or whitespace are semantically equal.
+type JSONStringValue struct {
+	basetypes.StringValue
+}
+
+// NewJSONStringValue creates known JSON string value.
+func NewJSONStringValue(s string) JSONStringValue {
+	return JSONStringValue{StringValue: basetypes.NewStringValue(s)}
+}
+
+func (v JSONStringValue) Type(_ context.Context) attr.Type {
+	return JSONStringType{}
+}
+
+func (v JSONStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(JSONStringValue)
+	if !ok {
+		return false
+	}
+	return v.StringValue.Equal(other.StringValue)
+}
+
+func (v JSONStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(JSONStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	var a, b any
+	if json.Unmarshal([]byte(v.ValueString()), &a) != nil || json.Unmarshal([]byte(newValue.ValueString()), &b) != nil {
+		return false, nil
+	}
+	return reflect.DeepEqual(a, b), nil
+}
+
+func (v JSONStringValue) ValidateAttribute(_ context.Context, req xattr.ValidateAttributeRequest, resp *xattr.ValidateAttributeResponse) {
+	if v.IsNull() || v.IsUnknown() {
+		return
+	}
+	if !json.Valid([]byte(v.ValueString())) {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid JSON String Value",
+			fmt.Sprintf("A string value was provided that is not valid JSON. Use jsonencode(...) to build the value.\n\nGiven Value: %s", v.ValueString()),
+		)
+	}
+}
+
+// toRaw decodes value into API object. Invalid JSON (rejected by ValidateAttribute) is sent as is.
+func (v JSONStringValue) toRaw() any {
+	var out any
+	if err := json.Unmarshal([]byte(v.ValueString()), &out); err != nil {
+		return v.ValueString()
+	}
+	return out
+}
+
+// stateValue converts JSON decoded raw state value of type t for BuildAttrValueFromAny.
+// In state JSON string attributes are stored encoded, so their strings are taken as is
+// rather than encoded again as API values. Other values (including objects of legacy states) are returned unchanged.
+func stateValue(t attr.Type, v any) any {
+	switch tt := t.(type) {
+	case JSONStringType:
+		if s, ok := v.(string); ok {
+			return NewJSONStringValue(s)
+		}
+	case types.ListType, types.SetType:
+		items, ok := v.([]any)
+		if !ok {
+			return v
+		}
+		elemType := tt.(attr.TypeWithElementType).ElementType()
+		out := make([]any, len(items))
+		for i, item := range items {
+			out[i] = stateValue(elemType, item)
+		}
+		return out
+	case types.MapType:
+		items, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		out := make(map[string]any, len(items))
+		for k, item := range items {
+			out[k] = stateValue(tt.ElemType, item)
+		}
+		return out
+	case types.ObjectType:
+		items, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		out := make(map[string]any, len(items))
+		for k, item := range items {
+			out[k] = stateValue(tt.AttrTypes[k], item)
+		}
+		return out
+	}
+	return v
+}
@@ -0,0 +1,102 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/attr/xattr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestJSONString_RoundTrip(t *testing.T) {
+	typ := JSONStringType{}
+
+	val, err := BuildAttrValueFromAny(typ, map[string]any{"b": []any{1, 2}, "a": "x"})
+	require.NoError(t, err)
+	require.Equal(t, NewJSONStringValue(`{"a":"x","b":[1,2]}`), val)
+	require.Equal(t, map[string]any{"a": "x", "b": []any{float64(1), float64(2)}}, ConvertAttrValueToRaw(val, typ))
+
+	// API strings are encoded even if they look like JSON, so they are sent back as strings.
+	for _, s := range []string{"123", "true", `{"a": "x"}`} {
+		val, err = BuildAttrValueFromAny(typ, s)
+		require.NoError(t, err)
+		require.Equal(t, s, ConvertAttrValueToRaw(val, typ), s)
+	}
+
+	val, err = BuildAttrValueFromAny(typ, nil)
+	require.NoError(t, err)
+	require.True(t, val.IsNull())
+	require.IsType(t, JSONStringValue{}, val)
+
+	val, err = BuildAttrValueFromAny(typ, tftypes.NewValue(tftypes.String, `[1]`))
+	require.NoError(t, err)
+	require.Equal(t, NewJSONStringValue(`[1]`), val)
+
+	list, err := BuildAttrValueFromAny(types.ListType{ElemType: typ}, []any{map[string]any{"k": true}})
+	require.NoError(t, err)
+	require.Equal(t, NewJSONStringValue(`{"k":true}`), list.(types.List).Elements()[0])
+}
+
+func TestJSONString_SemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	prior := NewJSONStringValue(`{"a":1,"b":{"c":[1,2]}}`)
+
+	equal, diags := prior.StringSemanticEquals(ctx, NewJSONStringValue("{\n  \"b\": {\"c\": [1, 2]},\n  \"a\": 1\n}"))
+	require.False(t, diags.HasError())
+	require.True(t, equal)
+
+	// Order of array elements is significant.
+	equal, _ = prior.StringSemanticEquals(ctx, NewJSONStringValue(`{"a":1,"b":{"c":[2,1]}}`))
+	require.False(t, equal)
+
+	_, diags = prior.StringSemanticEquals(ctx, types.StringValue(`{}`))
+	require.True(t, diags.HasError())
+}
+
+func TestJSONString_Validate(t *testing.T) {
+	req := xattr.ValidateAttributeRequest{Path: path.Root("config")}
+
+	resp := &xattr.ValidateAttributeResponse{}
+	NewJSONStringValue(`{"a": 1}`).ValidateAttribute(context.Background(), req, resp)
+	require.False(t, resp.Diagnostics.HasError())
+
+	resp = &xattr.ValidateAttributeResponse{}
+	NewJSONStringValue(`{a: 1}`).ValidateAttribute(context.Background(), req, resp)
+	require.True(t, resp.Diagnostics.HasError())
+}
+
+func TestJSONString_FromRawState(t *testing.T) {
+	typ := JSONStringType{}
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"acl":    rschema.StringAttribute{Optional: true, CustomType: typ},
+		"legacy": rschema.StringAttribute{Optional: true, CustomType: typ},
+		"rules":  rschema.ListAttribute{Optional: true, ElementType: typ},
+		"nested": rschema.SingleNestedAttribute{Optional: true, Attributes: map[string]rschema.Attribute{
+			"settings": rschema.StringAttribute{Optional: true, CustomType: typ},
+		}},
+	}}
+	raw := make(map[string]attr.Value)
+	for name, a := range schema.Attributes {
+		raw[name], _ = BuildAttrValueFromAny(a.GetType(), nil)
+	}
+	tf := NewTFStateMust(raw, schema, &TFStateHints{})
+	require.NoError(t, tf.FillFromRawState(map[string]any{
+		// Stored state keeps encoded JSON strings.
+		"acl":    `{"a": 1}`,
+		"rules":  []any{`"123"`},
+		"nested": map[string]any{"settings": `[1]`},
+		// Legacy state stored free-form object as is.
+		"legacy": map[string]any{"b": true},
+	}))
+	require.Equal(t, NewJSONStringValue(`{"a": 1}`), tf.Get("acl"))
+	require.Equal(t, NewJSONStringValue(`"123"`), tf.Get("rules").(types.List).Elements()[0])
+	require.Equal(t, NewJSONStringValue(`[1]`), tf.Get("nested").(types.Object).Attributes()["settings"])
+	require.Equal(t, NewJSONStringValue(`{"b":true}`), tf.Get("legacy"))
+}
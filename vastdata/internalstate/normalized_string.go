@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"fmt"
+	"regexp"
+	"strconv"
+	"strings"
+	"time"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ apiStringType                              = NormalizedStringType{}
+	_ apiStringValue                             = NormalizedStringValue{}
+	_ basetypes.StringValuableWithSemanticEquals = NormalizedStringValue{}
+)
+
+// NormalizedKind selects how values of NormalizedStringType are compared.
+type NormalizedKind string
+
+const (
+	// NormalizedDuration compares durations by length: "7d", "168h" and "7 00:00:00" are equal.
+	// Accepted formats are plain number of seconds, sequences of <integer><unit> (s/m/h/d/w/y, e.g. "90m", "1d12h")
+	// and "[<days> ]HH:MM:SS" (see ValidatorRetentionFormat and ValidatorGracePeriodFormat).
+	NormalizedDuration NormalizedKind = "duration"
+	// NormalizedPath compares filesystem paths ignoring trailing slash: "/a/b/" and "/a/b" are equal.
+	NormalizedPath NormalizedKind = "path"
+)
+
+// NormalizedStringType is the type of string attribute which VMS may return in different but equivalent form
+// (eg "7d" is returned as "168h"). Values are sent to API as is and compared semantically, so such
+// normalization does not produce diffs.
+type NormalizedStringType struct {
+	basetypes.StringType
+	Kind NormalizedKind
+}
+
+// NewDurationType creates string type with duration semantic equality.
+func NewDurationType() NormalizedStringType {
+	return NormalizedStringType{Kind: NormalizedDuration}
+}
+
+// NewPathType creates string type with filesystem path semantic equality.
+func NewPathType() NormalizedStringType {
+	return NormalizedStringType{Kind: NormalizedPath}
+}
+
+func (t NormalizedStringType) Equal(o attr.Type) bool {
+	other, ok := o.(NormalizedStringType)
+	if !ok {
+		return false
+	}
+	return t.Kind == other.Kind
+}
+
+func (t NormalizedStringType) String() string {
+	return fmt.Sprintf("NormalizedStringType[%s]", t.Kind)
+}
+
+func (t NormalizedStringType) ValueType(_ context.Context) attr.Value {
+	return NormalizedStringValue{typ: t}
+}
+
+func (t NormalizedStringType) ValueFromString(_ context.Context, in basetypes.StringValue) (basetypes.StringValuable, diag.Diagnostics) {
+	return NormalizedStringValue{StringValue: in, typ: t}, nil
+}
+
+func (t NormalizedStringType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.StringType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return NormalizedStringValue{StringValue: val.(basetypes.StringValue), typ: t}, nil
+}
+
+func (t NormalizedStringType) nullValue() attr.Value {
+	return NormalizedStringValue{StringValue: basetypes.NewStringNull(), typ: t}
+}
+
+func (t NormalizedStringType) fromRaw(raw any) (attr.Value, error) {
+	return NewNormalizedStringValue(t, fmt.Sprintf("%v", raw)), nil
+}
+
+// normalize returns canonical form of s. The second value is false if s cannot be normalized.
+func (t NormalizedStringType) normalize(s string) (string, bool) {
+	switch t.Kind {
+	case NormalizedDuration:
+		d, err := ParseDuration(s)
+		if err != nil {
+			return "", false
+		}
+		return d.String(), true
+	case NormalizedPath:
+		if trimmed := strings.TrimRight(s, "/"); trimmed != "" || s == "" {
+			return trimmed, true
+		}
+		return "/", true
+	default:
+		return s, true
+	}
+}
+
+// NormalizedStringValue is the value of NormalizedStringType.
+type NormalizedStringValue struct {
+	basetypes.StringValue
+	typ NormalizedStringType
+}
+
+// NewNormalizedStringValue creates known value of the given type.
+func NewNormalizedStringValue(t NormalizedStringType, s string) NormalizedStringValue {
+	return NormalizedStringValue{StringValue: basetypes.NewStringValue(s), typ: t}
+}
+
+func (v NormalizedStringValue) Type(_ context.Context) attr.Type {
+	return v.typ
+}
+
+func (v NormalizedStringValue) Equal(o attr.Value) bool {
+	other, ok := o.(NormalizedStringValue)
+	if !ok {
+		return false
+	}
+	return v.typ.Equal(other.typ) && v.StringValue.Equal(other.StringValue)
+}
+
+func (v NormalizedStringValue) StringSemanticEquals(_ context.Context, newValuable basetypes.StringValuable) (bool, diag.Diagnostics) {
+	var diags diag.Diagnostics
+	newValue, ok := newValuable.(NormalizedStringValue)
+	if !ok {
+		diags.AddError(
+			"Semantic Equality Check Error",
+			fmt.Sprintf("Expected value type %T, got %T. Please report this to the provider developers.", v, newValuable),
+		)
+		return false, diags
+	}
+	a, okA := v.typ.normalize(v.ValueString())
+	b, okB := v.typ.normalize(newValue.ValueString())
+	return okA && okB && a == b, nil
+}
+
+func (v NormalizedStringValue) toRaw() any {
+	return v.ValueString()
+}
+
+var (
+	durationUnitsRegex = regexp.MustCompile(`^(?:[0-9]+[smhdwy])+$`)
+	durationUnitRegex  = regexp.MustCompile(`([0-9]+)([smhdwy])`)
+	durationClockRegex = regexp.MustCompile(`^(?:([0-9]+) )?([0-9]{1,2}):([0-9]{2}):([0-9]{2})$`)
+)
+
+var durationUnits = map[string]time.Duration{
+	"s": time.Second,
+	"m": time.Minute,
+	"h": time.Hour,
+	"d": 24 * time.Hour,
+	"w": 7 * 24 * time.Hour,
+	"y": 365 * 24 * time.Hour,
+}
+
+// ParseDuration parses VMS duration: plain number of seconds, sequence of <integer><unit>
+// (s/m/h/d/w/y, e.g. "7d", "1d12h") or "[<days> ]HH:MM:SS" (e.g. "02:00:00", "1 12:00:00").
+func ParseDuration(s string) (time.Duration, error) {
+	s = strings.TrimSpace(s)
+	if n, err := strconv.ParseInt(s, 10, 64); err == nil && n >= 0 {
+		return time.Duration(n) * time.Second, nil
+	}
+	if durationUnitsRegex.MatchString(s) {
+		var total time.Duration
+		for _, m := range durationUnitRegex.FindAllStringSubmatch(s, -1) {
+			n, err := strconv.ParseInt(m[1], 10, 64)
+			if err != nil {
+				return 0, fmt.Errorf("invalid duration %q: %w", s, err)
+			}
+			total += time.Duration(n) * durationUnits[m[2]]
+		}
+		return total, nil
+	}
+	if m := durationClockRegex.FindStringSubmatch(s); m != nil {
+		var total time.Duration
+		if m[1] != "" {
+			days, _ := strconv.ParseInt(m[1], 10, 64)
+			total += time.Duration(days) * durationUnits["d"]
+		}
+		for i, unit := range []time.Duration{time.Hour, time.Minute, time.Second} {
+			n, _ := strconv.ParseInt(m[i+2], 10, 64)
+			total += time.Duration(n) * unit
+		}
+		return total, nil
+	}
+	return 0, fmt.Errorf("invalid duration %q: expected <integer><unit> (e.g. 7d, 12h) or [<days> ]HH:MM:SS", s)
+}
@@ -0,0 +1,71 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+	"time"
+
+	"github.com/stretchr/testify/require"
+)
+
+func TestNormalizedString_ParseDuration(t *testing.T) {
+	for in, expected := range map[string]time.Duration{
+		"7d":         7 * 24 * time.Hour,
+		"168h":       168 * time.Hour,
+		"90m":        90 * time.Minute,
+		"1d12h":      36 * time.Hour,
+		"2w":         14 * 24 * time.Hour,
+		"1y":         365 * 24 * time.Hour,
+		"7200":       2 * time.Hour,
+		"02:00:00":   2 * time.Hour,
+		"1 12:00:00": 36 * time.Hour,
+	} {
+		d, err := ParseDuration(in)
+		require.NoError(t, err, in)
+		require.Equal(t, expected, d, in)
+	}
+	for _, in := range []string{"", "d", "7x", "-1h", "1:2", "7 days"} {
+		_, err := ParseDuration(in)
+		require.Error(t, err, in)
+	}
+}
+
+func TestNormalizedString_SemanticEquals(t *testing.T) {
+	ctx := context.Background()
+	equal := func(typ NormalizedStringType, a, b string) bool {
+		eq, diags := NewNormalizedStringValue(typ, a).StringSemanticEquals(ctx, NewNormalizedStringValue(typ, b))
+		require.False(t, diags.HasError())
+		return eq
+	}
+
+	duration := NewDurationType()
+	require.True(t, equal(duration, "7d", "168h"))
+	require.True(t, equal(duration, "90m", "01:30:00"))
+	require.False(t, equal(duration, "7d", "7h"))
+	require.False(t, equal(duration, "bogus", "bogus"))
+
+	p := NewPathType()
+	require.True(t, equal(p, "/a/b/", "/a/b"))
+	require.True(t, equal(p, "/", "//"))
+	require.False(t, equal(p, "/a/b", "/a/c"))
+	require.False(t, equal(p, "/", ""))
+
+	require.False(t, duration.Equal(p))
+}
+
+func TestNormalizedString_RoundTrip(t *testing.T) {
+	typ := NewDurationType()
+
+	val, err := BuildAttrValueFromAny(typ, "168h")
+	require.NoError(t, err)
+	require.Equal(t, NewNormalizedStringValue(typ, "168h"), val)
+	// Values are sent as configured.
+	require.Equal(t, "7d", ConvertAttrValueToRaw(NewNormalizedStringValue(typ, "7d"), typ))
+
+	val, err = BuildAttrValueFromAny(typ, nil)
+	require.NoError(t, err)
+	require.True(t, val.IsNull())
+	require.Equal(t, typ, val.Type(context.Background()))
+}
@@ -0,0 +1,224 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+)
+
+var (
+	_ apiObjectType  = OneOfType{}
+	_ apiObjectValue = OneOfValue{}
+)
+
+// OneOfType is the object type of polymorphic (oneOf/anyOf) attribute.
+// In Terraform polymorphic value is an object with one optional nested object per variant,
+// while API expects and returns flat object of the chosen variant, optionally tagged with discriminator property.
+// ConvertAttrValueToRaw and BuildAttrValueFromAny translate between these two shapes.
+type OneOfType struct {
+	basetypes.ObjectType
+	// Discriminator is the API property which selects variant. Empty if variants are matched by their properties.
+	Discriminator string
+	// Variants maps variant attribute name to its discriminator value.
+	Variants map[string]string
+}
+
+// NewOneOfType creates polymorphic object type from variant attribute types and resolved OneOf metadata.
+func NewOneOfType(attrTypes map[string]attr.Type, oneOf *client.OneOf) OneOfType {
+	return OneOfType{
+		ObjectType:    basetypes.ObjectType{AttrTypes: attrTypes},
+		Discriminator: oneOf.Discriminator,
+		Variants:      oneOf.Variants,
+	}
+}
+
+func (t OneOfType) Equal(o attr.Type) bool {
+	other, ok := o.(OneOfType)
+	if !ok {
+		return false
+	}
+	return t.Discriminator == other.Discriminator &&
+		maps.Equal(t.Variants, other.Variants) &&
+		t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t OneOfType) String() string {
+	return fmt.Sprintf("OneOfType[%s]", t.ObjectType.String())
+}
+
+func (t OneOfType) ValueType(_ context.Context) attr.Value {
+	return OneOfValue{typ: t}
+}
+
+func (t OneOfType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.ObjectType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return t.wrap(val.(basetypes.ObjectValue)), nil
+}
+
+func (t OneOfType) ValueFromObject(_ context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	return OneOfValue{ObjectValue: in, typ: t}, nil
+}
+
+func (t OneOfType) nullValue() attr.Value {
+	return NewOneOfNull(t)
+}
+
+func (t OneOfType) wrap(obj basetypes.ObjectValue) attr.Value {
+	return OneOfValue{ObjectValue: obj, typ: t}
+}
+
+// variantNames returns variant attribute names in stable order.
+func (t OneOfType) variantNames() []string {
+	return slices.Sorted(maps.Keys(t.AttrTypes))
+}
+
+// fromRaw builds polymorphic value from flat API object.
+// Values which are already in Terraform shape (keyed by variant names) are accepted as well.
+func (t OneOfType) fromRaw(raw map[string]any) (attr.Value, error) {
+	if len(raw) == 0 {
+		return NewOneOfNull(t), nil
+	}
+	if t.isTerraformShape(raw) {
+		obj, err := BuildAttrValueFromAny(t.ObjectType, raw)
+		if err != nil {
+			return nil, err
+		}
+		return t.wrap(obj.(basetypes.ObjectValue)), nil
+	}
+
+	chosen := t.MatchVariant(raw)
+	if chosen == "" {
+		return nil, fmt.Errorf("object does not match any of variants %v", t.variantNames())
+	}
+	flat := maps.Clone(raw)
+	if t.Discriminator != "" {
+		delete(flat, t.Discriminator)
+	}
+
+	attrs := make(map[string]attr.Value, len(t.AttrTypes))
+	for name, typ := range t.AttrTypes {
+		if name != chosen {
+			attrs[name], _ = BuildAttrValueFromAny(typ, nil)
+			continue
+		}
+		val, err := BuildAttrValueFromAny(typ, flat)
+		if err != nil {
+			return nil, fmt.Errorf("variant %q: %w", name, err)
+		}
+		attrs[name] = val
+	}
+	obj, diags := basetypes.NewObjectValue(t.AttrTypes, attrs)
+	if diags.HasError() {
+		return nil, fmt.Errorf("objectValue: %s", diags)
+	}
+	return t.wrap(obj), nil
+}
+
+func (t OneOfType) isTerraformShape(raw map[string]any) bool {
+	for k := range raw {
+		if _, ok := t.AttrTypes[k]; !ok {
+			return false
+		}
+	}
+	return true
+}
+
+// MatchVariant returns name of variant attribute for flat API object (empty if none matches).
+// Variant is selected by discriminator value if possible.
+// Otherwise the variant that knows all keys of raw object and shares most of them wins.
+func (t OneOfType) MatchVariant(raw map[string]any) string {
+	if t.Discriminator != "" {
+		if value, ok := raw[t.Discriminator].(string); ok {
+			for _, name := range t.variantNames() {
+				if t.Variants[name] == value {
+					return name
+				}
+			}
+		}
+	}
+
+	best, bestScore := "", -1
+	for _, name := range t.variantNames() {
+		fields := apiAttributeNames(t.AttrTypes[name])
+		matched, unknown := 0, 0
+		for k := range raw {
+			if k == t.Discriminator {
+				continue
+			}
+			if fields[k] {
+				matched++
+			} else {
+				unknown++
+			}
+		}
+		// Variants which do not know some keys are the last resort.
+		score := matched
+		if unknown == 0 {
+			score += len(raw) + 1
+		}
+		if matched > 0 && score > bestScore {
+			best, bestScore = name, score
+		}
+	}
+	return best
+}
+
+// OneOfValue is the value of OneOfType.
+type OneOfValue struct {
+	basetypes.ObjectValue
+	typ OneOfType
+}
+
+// NewOneOfNull creates null polymorphic value.
+func NewOneOfNull(t OneOfType) OneOfValue {
+	return OneOfValue{ObjectValue: basetypes.NewObjectNull(t.AttrTypes), typ: t}
+}
+
+func (v OneOfValue) Type(_ context.Context) attr.Type {
+	return v.typ
+}
+
+func (v OneOfValue) Equal(o attr.Value) bool {
+	other, ok := o.(OneOfValue)
+	if !ok {
+		return false
+	}
+	return v.typ.Equal(other.typ) && v.ObjectValue.Equal(other.ObjectValue)
+}
+
+func (v OneOfValue) ToObjectValue(_ context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
+	return v.ObjectValue, nil
+}
+
+// toRaw converts polymorphic value into flat API object of the chosen variant.
+func (v OneOfValue) toRaw() any {
+	t := v.typ
+	attrs := v.Attributes()
+	for _, name := range t.variantNames() {
+		variant, ok := attrs[name]
+		if !ok || variant.IsNull() || variant.IsUnknown() {
+			continue
+		}
+		out, _ := ConvertAttrValueToRaw(variant, t.AttrTypes[name]).(map[string]any)
+		if out == nil {
+			out = make(map[string]any)
+		}
+		if t.Discriminator != "" {
+			out[t.Discriminator] = t.Variants[name]
+		}
+		return out
+	}
+	return nil
+}
@@ -0,0 +1,96 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+)
+
+var (
+	prefixVariantType = types.ObjectType{AttrTypes: map[string]attr.Type{"prefix": types.StringType}}
+	tagVariantType    = types.ObjectType{AttrTypes: map[string]attr.Type{"key": types.StringType, "value": types.StringType}}
+)
+
+func filterType(discriminator string) OneOfType {
+	return NewOneOfType(
+		map[string]attr.Type{"by_prefix": prefixVariantType, "by_tag": tagVariantType},
+		&client.OneOf{
+			Discriminator: discriminator,
+			Variants:      map[string]string{"by_prefix": "prefix", "by_tag": "tag"},
+		},
+	)
+}
+
+func TestOneOf_Discriminator(t *testing.T) {
+	typ := filterType("type")
+
+	val, err := BuildAttrValueFromAny(typ, map[string]any{"type": "tag", "key": "env", "value": "prod"})
+	require.NoError(t, err)
+	oneOf := val.(OneOfValue)
+	require.True(t, oneOf.Attributes()["by_prefix"].IsNull())
+	require.Equal(t, "env", oneOf.Attributes()["by_tag"].(types.Object).Attributes()["key"].(types.String).ValueString())
+	require.True(t, typ.Equal(oneOf.Type(context.Background())))
+
+	// Back to flat API shape with discriminator.
+	require.Equal(t,
+		map[string]any{"type": "tag", "key": "env", "value": "prod"},
+		ConvertAttrValueToRaw(val, typ),
+	)
+}
+
+func TestOneOf_MatchByProperties(t *testing.T) {
+	typ := filterType("")
+
+	val, err := BuildAttrValueFromAny(typ, map[string]any{"prefix": "logs/"})
+	require.NoError(t, err)
+	require.False(t, val.(OneOfValue).Attributes()["by_prefix"].IsNull())
+	require.True(t, val.(OneOfValue).Attributes()["by_tag"].IsNull())
+	require.Equal(t, map[string]any{"prefix": "logs/"}, ConvertAttrValueToRaw(val, typ))
+
+	// Terraform shaped values (eg from raw state) are accepted as is.
+	val, err = BuildAttrValueFromAny(typ, map[string]any{"by_tag": map[string]any{"key": "env", "value": "dev"}})
+	require.NoError(t, err)
+	require.Equal(t, map[string]any{"key": "env", "value": "dev"}, ConvertAttrValueToRaw(val, typ))
+
+	_, err = BuildAttrValueFromAny(typ, map[string]any{"suffix": ".log"})
+	require.Error(t, err)
+
+	val, err = BuildAttrValueFromAny(typ, nil)
+	require.NoError(t, err)
+	require.True(t, val.IsNull())
+	require.Nil(t, ConvertAttrValueToRaw(val, typ))
+}
+
+func TestOneOf_FromTerraform(t *testing.T) {
+	typ := filterType("type")
+	tfType := typ.TerraformType(context.Background())
+	objType := tfType.(tftypes.Object)
+
+	tfVal := tftypes.NewValue(tfType, map[string]tftypes.Value{
+		"by_prefix": tftypes.NewValue(objType.AttributeTypes["by_prefix"], map[string]tftypes.Value{
+			"prefix": tftypes.NewValue(tftypes.String, "logs/"),
+		}),
+		"by_tag": tftypes.NewValue(objType.AttributeTypes["by_tag"], nil),
+	})
+	val, err := BuildAttrValueFromAny(typ, tfVal)
+	require.NoError(t, err)
+	require.IsType(t, OneOfValue{}, val)
+	require.Equal(t, map[string]any{"type": "prefix", "prefix": "logs/"}, ConvertAttrValueToRaw(val, typ))
+
+	fromFramework, err := typ.ValueFromTerraform(context.Background(), tfVal)
+	require.NoError(t, err)
+	require.True(t, val.Equal(fromFramework))
+
+	// Polymorphic values nested in lists keep their custom type.
+	listType := types.ListType{ElemType: typ}
+	list, err := BuildAttrValueFromAny(listType, []any{map[string]any{"type": "prefix", "prefix": "a/"}})
+	require.NoError(t, err)
+	require.Equal(t, []any{map[string]any{"type": "prefix", "prefix": "a/"}}, ConvertAttrValueToRaw(list, listType))
+}
@@ -0,0 +1,122 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"fmt"
+	"maps"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+)
+
+var (
+	_ apiObjectType  = RenamedObjectType{}
+	_ apiObjectValue = RenamedObjectValue{}
+)
+
+// RenamedObjectType is the object type of nested object whose API property names are not valid
+// Terraform identifiers (eg "start-at"). Attributes use sanitized snake_case names (eg "start_at")
+// and keys are renamed back and forth by ConvertAttrValueToRaw and BuildAttrValueFromAny.
+type RenamedObjectType struct {
+	basetypes.ObjectType
+	// APINames maps sanitized attribute name to original API property name.
+	APINames map[string]string
+}
+
+// NewRenamedObjectType creates object type from attribute types and sanitized -> API names mapping.
+func NewRenamedObjectType(attrTypes map[string]attr.Type, apiNames map[string]string) RenamedObjectType {
+	return RenamedObjectType{
+		ObjectType: basetypes.ObjectType{AttrTypes: attrTypes},
+		APINames:   apiNames,
+	}
+}
+
+func (t RenamedObjectType) Equal(o attr.Type) bool {
+	other, ok := o.(RenamedObjectType)
+	if !ok {
+		return false
+	}
+	return maps.Equal(t.APINames, other.APINames) && t.ObjectType.Equal(other.ObjectType)
+}
+
+func (t RenamedObjectType) String() string {
+	return fmt.Sprintf("RenamedObjectType[%s]", t.ObjectType.String())
+}
+
+func (t RenamedObjectType) ValueType(_ context.Context) attr.Value {
+	return RenamedObjectValue{typ: t}
+}
+
+func (t RenamedObjectType) ValueFromTerraform(ctx context.Context, in tftypes.Value) (attr.Value, error) {
+	val, err := t.ObjectType.ValueFromTerraform(ctx, in)
+	if err != nil {
+		return nil, err
+	}
+	return t.wrap(val.(basetypes.ObjectValue)), nil
+}
+
+func (t RenamedObjectType) ValueFromObject(_ context.Context, in basetypes.ObjectValue) (basetypes.ObjectValuable, diag.Diagnostics) {
+	return RenamedObjectValue{ObjectValue: in, typ: t}, nil
+}
+
+func (t RenamedObjectType) nullValue() attr.Value {
+	return t.wrap(basetypes.NewObjectNull(t.AttrTypes))
+}
+
+func (t RenamedObjectType) wrap(obj basetypes.ObjectValue) attr.Value {
+	return RenamedObjectValue{ObjectValue: obj, typ: t}
+}
+
+// fromRaw builds value from API object. Keys which are already sanitized are accepted as well.
+func (t RenamedObjectType) fromRaw(raw map[string]any) (attr.Value, error) {
+	renamed := maps.Clone(raw)
+	for name, apiName := range t.APINames {
+		if v, ok := renamed[apiName]; ok {
+			delete(renamed, apiName)
+			renamed[name] = v
+		}
+	}
+	obj, err := BuildAttrValueFromAny(t.ObjectType, renamed)
+	if err != nil {
+		return nil, err
+	}
+	return t.wrap(obj.(basetypes.ObjectValue)), nil
+}
+
+// RenamedObjectValue is the value of RenamedObjectType.
+type RenamedObjectValue struct {
+	basetypes.ObjectValue
+	typ RenamedObjectType
+}
+
+func (v RenamedObjectValue) Type(_ context.Context) attr.Type {
+	return v.typ
+}
+
+func (v RenamedObjectValue) Equal(o attr.Value) bool {
+	other, ok := o.(RenamedObjectValue)
+	if !ok {
+		return false
+	}
+	return v.typ.Equal(other.typ) && v.ObjectValue.Equal(other.ObjectValue)
+}
+
+func (v RenamedObjectValue) ToObjectValue(_ context.Context) (basetypes.ObjectValue, diag.Diagnostics) {
+	return v.ObjectValue, nil
+}
+
+// toRaw converts value into API object with original property names.
+func (v RenamedObjectValue) toRaw() any {
+	out, _ := ConvertAttrValueToRaw(v.ObjectValue, v.typ.ObjectType).(map[string]any)
+	for name, apiName := range v.typ.APINames {
+		if val, ok := out[name]; ok {
+			delete(out, name)
+			out[apiName] = val
+		}
+	}
+	return out
+}
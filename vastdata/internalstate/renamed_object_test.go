@@ -0,0 +1,37 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestRenamedObject_RoundTrip(t *testing.T) {
+	typ := NewRenamedObjectType(
+		map[string]attr.Type{"every": types.StringType, "start_at": types.StringType, "keep_local": types.StringType},
+		map[string]string{"start_at": "start-at", "keep_local": "keep-local"},
+	)
+	listType := types.ListType{ElemType: typ}
+
+	val, err := BuildAttrValueFromAny(listType, []any{map[string]any{"every": "1D", "start-at": "2025-07-27 20:10:35"}})
+	require.NoError(t, err)
+	frame := val.(types.List).Elements()[0].(RenamedObjectValue)
+	require.Equal(t, types.StringValue("2025-07-27 20:10:35"), frame.Attributes()["start_at"])
+	require.True(t, frame.Attributes()["keep_local"].IsNull())
+	require.True(t, typ.Equal(frame.Type(context.Background())))
+
+	require.Equal(t,
+		[]any{map[string]any{"every": "1D", "start-at": "2025-07-27 20:10:35", "keep-local": nil}},
+		ConvertAttrValueToRaw(val, listType),
+	)
+
+	// Already sanitized keys (eg from raw state) are accepted as well.
+	val, err = BuildAttrValueFromAny(typ, map[string]any{"keep_local": "2D"})
+	require.NoError(t, err)
+	require.Equal(t, types.StringValue("2D"), val.(RenamedObjectValue).Attributes()["keep_local"])
+}
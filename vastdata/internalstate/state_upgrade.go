@@ -0,0 +1,198 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"encoding/json"
+	"fmt"
+	"strings"
+)
+
+// StateUpgradeOp is a single declarative transformation applied to prior (JSON decoded) state.
+// Field paths are attribute names; nested object attributes can be addressed using dots (e.g. "share_acl.enabled").
+type StateUpgradeOp interface {
+	Apply(state map[string]any) error
+}
+
+// StateUpgrade describes operations required to move state stored with schema version
+// FromVersion to FromVersion+1.
+//
+// Example:
+//
+//	StateUpgrade{
+//	    FromVersion: 0,
+//	    Ops: []StateUpgradeOp{
+//	        RenameField{From: "permissions_list", To: "permissions"},
+//	        RetypeField{Field: "share_acl", Convert: ToJSONString},
+//	        DropField{Field: "legacy_flag"},
+//	    },
+//	}
+type StateUpgrade struct {
+	FromVersion int64
+	Ops         []StateUpgradeOp
+}
+
+// RenameField moves value of attribute From to attribute To.
+type RenameField struct {
+	From string
+	To   string
+}
+
+// DropField removes attribute from the state.
+type DropField struct {
+	Field string
+}
+
+// RetypeField converts attribute value using Convert. Null or absent values are left untouched.
+type RetypeField struct {
+	Field   string
+	Convert func(any) (any, error)
+}
+
+func (op RenameField) Apply(state map[string]any) error {
+	parent, key, ok := lookupStatePath(state, op.From)
+	if !ok {
+		return nil
+	}
+	val, exists := parent[key]
+	if !exists {
+		return nil
+	}
+	delete(parent, key)
+	dstParent, dstKey, ok := lookupStatePath(state, op.To)
+	if !ok {
+		return fmt.Errorf("rename %q -> %q: destination parent is not an object", op.From, op.To)
+	}
+	dstParent[dstKey] = val
+	return nil
+}
+
+func (op DropField) Apply(state map[string]any) error {
+	if parent, key, ok := lookupStatePath(state, op.Field); ok {
+		delete(parent, key)
+	}
+	return nil
+}
+
+func (op RetypeField) Apply(state map[string]any) error {
+	parent, key, ok := lookupStatePath(state, op.Field)
+	if !ok {
+		return nil
+	}
+	val, exists := parent[key]
+	if !exists || IsNil(val) {
+		return nil
+	}
+	converted, err := op.Convert(val)
+	if err != nil {
+		return fmt.Errorf("retype %q: %w", op.Field, err)
+	}
+	parent[key] = converted
+	return nil
+}
+
+// lookupStatePath returns the object holding the last path segment and the segment itself.
+func lookupStatePath(state map[string]any, fieldPath string) (map[string]any, string, bool) {
+	parts := strings.Split(fieldPath, ".")
+	current := state
+	for _, part := range parts[:len(parts)-1] {
+		next, ok := current[part].(map[string]any)
+		if !ok {
+			return nil, "", false
+		}
+		current = next
+	}
+	return current, parts[len(parts)-1], true
+}
+
+// --- Retype converters ---
+
+// ToJSONString encodes any value (typically an object) as JSON string.
+func ToJSONString(v any) (any, error) {
+	b, err := json.Marshal(v)
+	if err != nil {
+		return nil, err
+	}
+	return string(b), nil
+}
+
+// FromJSONString decodes JSON string into its structured value.
+func FromJSONString(v any) (any, error) {
+	s, ok := v.(string)
+	if !ok {
+		return nil, fmt.Errorf("expected string, got %T", v)
+	}
+	var out any
+	if err := json.Unmarshal([]byte(s), &out); err != nil {
+		return nil, err
+	}
+	return out, nil
+}
+
+// ToStringValue converts primitive value to its string representation.
+func ToStringValue(v any) (any, error) {
+	switch val := v.(type) {
+	case string:
+		return val, nil
+	case map[string]any, []any:
+		return nil, fmt.Errorf("cannot convert %T to string", v)
+	default:
+		return fmt.Sprintf("%v", val), nil
+	}
+}
+
+// ToInt64Value converts numeric or numeric string value to int64.
+func ToInt64Value(v any) (any, error) {
+	return ToInt(v)
+}
+
+// ToUniqueList removes duplicate elements from list (e.g. list converted to set).
+func ToUniqueList(v any) (any, error) {
+	list, ok := v.([]any)
+	if !ok {
+		return nil, fmt.Errorf("expected list, got %T", v)
+	}
+	seen := make(map[string]bool, len(list))
+	out := make([]any, 0, len(list))
+	for _, item := range list {
+		key, err := json.Marshal(item)
+		if err != nil {
+			return nil, err
+		}
+		if seen[string(key)] {
+			continue
+		}
+		seen[string(key)] = true
+		out = append(out, item)
+	}
+	return out, nil
+}
+
+// StateSchemaVersion returns the current schema version implied by registered upgrades.
+// Upgrades must cover versions 0..N-1 without gaps; the current version is N.
+func StateSchemaVersion(upgrades []StateUpgrade) (int64, error) {
+	for i, u := range upgrades {
+		if u.FromVersion != int64(i) {
+			return 0, fmt.Errorf("state upgrades must be ordered and contiguous: expected version %d at position %d, got %d", i, i, u.FromVersion)
+		}
+	}
+	return int64(len(upgrades)), nil
+}
+
+// ApplyStateUpgrades applies all upgrades starting at fromVersion up to the current version.
+func ApplyStateUpgrades(state map[string]any, upgrades []StateUpgrade, fromVersion int64) error {
+	if _, err := StateSchemaVersion(upgrades); err != nil {
+		return err
+	}
+	for _, u := range upgrades {
+		if u.FromVersion < fromVersion {
+			continue
+		}
+		for _, op := range u.Ops {
+			if err := op.Apply(state); err != nil {
+				return fmt.Errorf("upgrade from version %d: %w", u.FromVersion, err)
+			}
+		}
+	}
+	return nil
+}
@@ -0,0 +1,124 @@
+// Copyright (c) HashiCorp, Inc.
+
+package internalstate
+
+import (
+	"encoding/json"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyStateUpgrades(t *testing.T) {
+	tests := []struct {
+		name        string
+		priorJSON   string
+		upgrades    []StateUpgrade
+		fromVersion int64
+		expected    string
+		expectErr   bool
+	}{
+		{
+			name:      "rename top level field",
+			priorJSON: `{"id": 1, "permissions_list": ["a", "b"]}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RenameField{From: "permissions_list", To: "permissions"}}},
+			},
+			expected: `{"id": 1, "permissions": ["a", "b"]}`,
+		},
+		{
+			name:      "rename nested field",
+			priorJSON: `{"id": 1, "share_acl": {"enabled_flag": true}}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RenameField{From: "share_acl.enabled_flag", To: "share_acl.enabled"}}},
+			},
+			expected: `{"id": 1, "share_acl": {"enabled": true}}`,
+		},
+		{
+			name:      "drop field",
+			priorJSON: `{"id": 1, "legacy": "x"}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{DropField{Field: "legacy"}}},
+			},
+			expected: `{"id": 1}`,
+		},
+		{
+			name:      "object to json string",
+			priorJSON: `{"id": 1, "frames": {"every": "1D", "keep_local": "2D"}}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RetypeField{Field: "frames", Convert: ToJSONString}}},
+			},
+			expected: `{"id": 1, "frames": "{\"every\":\"1D\",\"keep_local\":\"2D\"}"}`,
+		},
+		{
+			name:      "list to set removes duplicates",
+			priorJSON: `{"protocols": ["NFS", "SMB", "NFS"]}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RetypeField{Field: "protocols", Convert: ToUniqueList}}},
+			},
+			expected: `{"protocols": ["NFS", "SMB"]}`,
+		},
+		{
+			name:      "null value is not converted",
+			priorJSON: `{"quota": null}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RetypeField{Field: "quota", Convert: ToInt64Value}}},
+			},
+			expected: `{"quota": null}`,
+		},
+		{
+			name:      "chain from intermediate version",
+			priorJSON: `{"a": "1", "b": 2}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{DropField{Field: "a"}}},
+				{FromVersion: 1, Ops: []StateUpgradeOp{RetypeField{Field: "a", Convert: ToInt64Value}}},
+				{FromVersion: 2, Ops: []StateUpgradeOp{RetypeField{Field: "b", Convert: ToStringValue}}},
+			},
+			fromVersion: 1,
+			expected:    `{"a": 1, "b": "2"}`,
+		},
+		{
+			name:      "non contiguous versions",
+			priorJSON: `{}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 1, Ops: []StateUpgradeOp{DropField{Field: "a"}}},
+			},
+			expectErr: true,
+		},
+		{
+			name:      "invalid conversion",
+			priorJSON: `{"a": "abc"}`,
+			upgrades: []StateUpgrade{
+				{FromVersion: 0, Ops: []StateUpgradeOp{RetypeField{Field: "a", Convert: ToInt64Value}}},
+			},
+			expectErr: true,
+		},
+	}
+
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := map[string]any{}
+			require.NoError(t, json.Unmarshal([]byte(tt.priorJSON), &state))
+			err := ApplyStateUpgrades(state, tt.upgrades, tt.fromVersion)
+			if tt.expectErr {
+				require.Error(t, err)
+				return
+			}
+			require.NoError(t, err)
+			out, err := json.Marshal(state)
+			require.NoError(t, err)
+			assert.JSONEq(t, tt.expected, string(out))
+		})
+	}
+}
+
+func TestStateSchemaVersion(t *testing.T) {
+	v, err := StateSchemaVersion(nil)
+	require.NoError(t, err)
+	assert.Equal(t, int64(0), v)
+
+	v, err = StateSchemaVersion([]StateUpgrade{{FromVersion: 0}, {FromVersion: 1}})
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), v)
+}
@@ -9,10 +9,12 @@ import (
 	"maps"
 	"slices"
 	"strings"
+	"time"
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	vast_client "github.com/vast-data/go-vast-client"
 
@@ -289,10 +291,12 @@ func (s *TFState) HasAttribute(attributeName string) bool {
 
 func (s *TFState) String(path string) string {
 	v := s.Get(path)
-	str, ok := v.(types.String)
+	// Custom string types (see CustomTypeMapping) are strings as well.
+	valuable, ok := v.(basetypes.StringValuable)
 	if !ok {
 		s.convPanic(fmt.Sprintf("not a sting at %q", path))
 	}
+	str, _ := valuable.ToStringValue(context.Background())
 	return str.ValueString()
 }
 
@@ -391,6 +395,8 @@ func (s *TFState) Get(path string) attr.Value {
 
 			if obj, ok := current.(types.Object); ok {
 				currentMap = obj.Attributes()
+			} else if obj, ok := current.(apiObjectValue); ok {
+				currentMap = obj.Attributes()
 			} else if i < len(parts)-1 {
 				s.convPanic(fmt.Sprintf(
 					"unexpected non-object at %q (%T)", strings.Join(partsToStrings(parts[:i+1]), "."), current),
@@ -568,6 +574,87 @@ func (s *TFState) FillFromRecordIncludingRequired(record Record, includeRequired
 	return nil
 }
 
+// FillFromReadRecord populates TF state from backend record during Read.
+// Same as FillFromRecord, except volatile fields (see VolatileFields) that already hold
+// a value keep it, so counters and timestamps do not produce drift on every refresh.
+func (s *TFState) FillFromReadRecord(record Record) error {
+	if record == nil {
+		return errors.New("record is nil")
+	}
+	volatile := s.VolatileFields()
+	if len(volatile) == 0 {
+		return s.FillFromRecord(record)
+	}
+	filtered := make(Record, len(record))
+	for key, rawVal := range record {
+		if contains(volatile, key) {
+			if v, ok := s.Raw[key]; ok && !v.IsNull() && !v.IsUnknown() {
+				continue
+			}
+		}
+		filtered[key] = rawVal
+	}
+	return s.FillFromRecord(filtered)
+}
+
+// VolatileFields returns fields whose remote changes are ignored on Read:
+// fields from VolatileFields hint and fields listed in "ignore_remote_changes" attribute.
+func (s *TFState) VolatileFields() []string {
+	var fields []string
+	if s.Hints != nil {
+		fields = append(fields, s.Hints.VolatileFields...)
+	}
+	if _, ok := s.Raw[IgnoreRemoteChangesField]; ok {
+		for _, item := range s.ToSlice(IgnoreRemoteChangesField) {
+			if name, ok := item.(string); ok && !contains(fields, name) {
+				fields = append(fields, name)
+			}
+		}
+	}
+	return fields
+}
+
+// DeleteTimeout returns delete timeout configured in "timeouts" attribute or zero if it is not configured.
+func (s *TFState) DeleteTimeout() (time.Duration, error) {
+	if !s.IsKnownAndNotNull(TimeoutsField) {
+		return 0, nil
+	}
+	timeouts, _ := ConvertAttrValueToRaw(s.Get(TimeoutsField), s.Type(TimeoutsField)).(map[string]any)
+	value, ok := timeouts["delete"].(string)
+	if !ok {
+		return 0, nil
+	}
+	timeout, err := time.ParseDuration(value)
+	if err != nil {
+		return 0, fmt.Errorf("invalid %s.delete %q: %w", TimeoutsField, value, err)
+	}
+	return timeout, nil
+}
+
+// FillFromRawState populates state from JSON decoded raw state (e.g. prior state during
+// state upgrade or move). Attributes absent from the current schema are ignored.
+func (s *TFState) FillFromRawState(raw map[string]any) error {
+	s.assertEnabled()
+	for k := range s.Raw {
+		v, ok := raw[k]
+		if !ok {
+			continue
+		}
+		if _, isSet := s.Type(k).(types.SetType); isSet {
+			// Lists retyped to sets may carry duplicates that sets reject.
+			if list, isList := v.([]any); isList {
+				v, _ = ToUniqueList(list)
+			}
+		}
+		val, err := BuildAttrValueFromAny(s.Type(k), stateValue(s.Type(k), v))
+		if err != nil {
+			return fmt.Errorf("attribute %q: %w", k, err)
+		}
+		s.Raw[k] = val
+	}
+	return nil
+}
+
 func (s *TFState) SetState(ctx context.Context, state *tfsdk.State) error {
 	for k, v := range s.Raw {
 		attrPath := path.Root(k)
@@ -584,6 +671,130 @@ func (s *TFState) SetState(ctx context.Context, state *tfsdk.State) error {
 	return nil
 }
 
+// SetEphemeralResult populates ephemeral resource result (Terraform 1.10+) from the current state values.
+func (s *TFState) SetEphemeralResult(ctx context.Context, result *tfsdk.EphemeralResultData) error {
+	for k, v := range s.Raw {
+		if diags := result.SetAttribute(ctx, path.Root(k), v); diags.HasError() {
+			return fmt.Errorf("set attribute %q: %s", k, diags.Errors())
+		}
+	}
+	return nil
+}
+
+// SetIdentity populates resource identity (Terraform 1.12+) from the current state values.
+// Set values are converted to lists as identity schema supports lists only.
+func (s *TFState) SetIdentity(ctx context.Context, identity *tfsdk.ResourceIdentity) error {
+	if identity == nil || identity.Schema == nil {
+		return nil
+	}
+	for k := range identity.Schema.GetAttributes() {
+		v, ok := s.Raw[k]
+		if !ok {
+			continue
+		}
+		if set, ok := v.(types.Set); ok {
+			if set.IsNull() || set.IsUnknown() {
+				v = types.ListNull(set.ElementType(ctx))
+			} else {
+				v = types.ListValueMust(set.ElementType(ctx), set.Elements())
+			}
+		}
+		if v.IsUnknown() {
+			continue
+		}
+		if diags := identity.SetAttribute(ctx, path.Root(k), v); diags.HasError() {
+			return fmt.Errorf("set identity attribute %q: %s", k, diags.Errors())
+		}
+	}
+	return nil
+}
+
+// FillFromIdentity copies non-null identity values (e.g. from an `import { identity = {...} }` block)
+// into the state. Values keep their declared types, so no string coercion is involved.
+func (s *TFState) FillFromIdentity(identity *tfsdk.ResourceIdentity) error {
+	s.assertEnabled()
+	if identity == nil || identity.Raw.IsNull() {
+		return fmt.Errorf("identity is empty")
+	}
+	values, err := FillFrameworkValues(identity.Raw, identity.Schema)
+	if err != nil {
+		return fmt.Errorf("decode identity: %w", err)
+	}
+	for k, v := range values {
+		if v.IsNull() || v.IsUnknown() {
+			continue
+		}
+		if !s.HasAttribute(k) {
+			return fmt.Errorf("identity field %q is not present in the resource schema", k)
+		}
+		if list, ok := v.(types.List); ok {
+			if setType, ok := s.Type(k).(types.SetType); ok {
+				v = types.SetValueMust(setType.ElemType, list.Elements())
+			}
+		}
+		s.SetOrAdd(k, v)
+	}
+	return nil
+}
+
+// FillWriteOnlyFromConfig copies write-only values from configuration.
+// Write-only values are always null in plan and state, configuration is the only place they are available.
+func (s *TFState) FillWriteOnlyFromConfig(config tfsdk.Config) error {
+	s.assertEnabled()
+	if s.Hints == nil || len(s.Hints.WriteOnlyFields) == 0 || config.Raw.IsNull() {
+		return nil
+	}
+	values, err := FillFrameworkValues(config.Raw, config.Schema)
+	if err != nil {
+		return fmt.Errorf("decode config: %w", err)
+	}
+	for k, v := range values {
+		if meta, ok := s.Meta[k]; ok && meta.WriteOnly {
+			s.Raw[k] = v
+		}
+	}
+	return nil
+}
+
+// writeOnlyVersionFields returns companion version attributes of write-only fields.
+// These attributes exist only in Terraform and are never sent to the API.
+func (s *TFState) writeOnlyVersionFields() []string {
+	if s.Hints == nil {
+		return nil
+	}
+	var fields []string
+	for _, name := range s.Hints.WriteOnlyFields {
+		fields = append(fields, WriteOnlyVersionField(name))
+	}
+	return fields
+}
+
+// terraformOnlyFields returns attributes that exist in Terraform schema only and are never sent to the API:
+// companion versions of write-only fields, name alternatives of reference fields, "ignore_remote_changes"
+// and "timeouts".
+func (s *TFState) terraformOnlyFields() []string {
+	fields := []string{IgnoreRemoteChangesField, TimeoutsField}
+	if s.Hints == nil {
+		return fields
+	}
+	fields = append(fields, s.writeOnlyVersionFields()...)
+	for name := range s.Hints.ReferenceFields {
+		fields = append(fields, name)
+	}
+	return fields
+}
+
+// writeOnlyVersionChanged reports whether companion version of write-only field differs between states.
+func (s *TFState) writeOnlyVersionChanged(other *TFState, field string) bool {
+	versionField := WriteOnlyVersionField(field)
+	v, ok := s.Raw[versionField]
+	if !ok {
+		return false
+	}
+	otherVal, ok := other.Raw[versionField]
+	return !ok || !v.Equal(otherVal)
+}
+
 // CopyNonEmptyFieldsTo copies only non-null and known fields from this TFState
 // to another, along with their associated attribute metadata.
 func (s *TFState) CopyNonEmptyFieldsTo(other *TFState) {
@@ -734,6 +945,18 @@ func (s *TFState) DiffFields(
 			continue
 		}
 
+		if contains(s.terraformOnlyFields(), k) {
+			continue // Terraform only attribute.
+		}
+		if meta.WriteOnly {
+			// Write-only values are absent in state so they cannot be compared:
+			// send them only when companion version attribute is changed.
+			if s.writeOnlyVersionChanged(other, k) {
+				diff[k] = ConvertAttrValueToRaw(v, valType)
+			}
+			continue
+		}
+
 		if otherVal, ok := other.Raw[k]; !ok || otherVal.IsNull() || otherVal.IsUnknown() || !v.Equal(otherVal) {
 			diff[k] = ConvertAttrValueToRaw(v, valType)
 		}
@@ -893,6 +1116,7 @@ func (s *TFState) GetCreateParams() vast_client.Params {
 		exclude = append(exclude, s.Hints.EditOnlyFields...)                                   // Edit only fields should not be set on creation.
 		exclude = append(exclude, slices.Collect(maps.Keys(s.Hints.DeleteOnlyBodyFields))...)  // Delete only fields should not be set on creation.
 		exclude = append(exclude, slices.Collect(maps.Keys(s.Hints.DeleteOnlyParamFields))...) // Delete only fields should not be set on creation.
+		exclude = append(exclude, s.terraformOnlyFields()...)                                  // Terraform only attributes.
 	}
 
 	createParams := s.GetFilteredValues(
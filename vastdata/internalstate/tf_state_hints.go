@@ -2,6 +2,8 @@
 
 package internalstate
 
+import "time"
+
 // TFStateHints defines metadata and overrides used during schema generation for
 // Terraform resources and data sources. These hints allow customizing required,
 // optional, excluded, and searchable fields beyond what is defined in the OpenAPI schema.
@@ -25,6 +27,13 @@ type TFStateHints struct {
 	// pairs are provided, any subset and order is accepted; keys must exist in the schema.
 	ImportFields []string
 
+	// IdentityFields defines field names that form the resource identity (Terraform 1.12+).
+	// Identity lets practitioners import with typed `import { identity = {...} }` blocks
+	// and is stored alongside the state. If empty, ImportFields are used, and if those are
+	// empty too, the identity falls back to "id". The first field is required for import,
+	// the rest are optional.
+	IdentityFields []string
+
 	// SearchableFields lists field names that should be treated as searchable
 	// when constructing lookup parameters (e.g., for API GET calls).
 	SearchableFields []string
@@ -58,12 +67,20 @@ type TFStateHints struct {
 	// by the backend and thus should not be treated as computed.
 	NotComputedSchemaFields []string
 
+	// DefaultSchemaFields lists optional fields whose OpenAPI "default" is a safe constant and becomes
+	// the schema default: the default is shown in the plan and removing the field from configuration reverts it.
+	// Fields of single nested objects are listed by dotted path (e.g. "static_limits.max_iops").
+	// Other OpenAPI defaults are not enforced, since the cluster may set different values on its own.
+	DefaultSchemaFields []string
+
 	// ReadOnlyFields indicates fields only for search IOW only read operations.
 	ReadOnlyFields []string
 
 	// WriteOnlyFields indicates fields whose values Terraform will not store
 	// in the plan or state artifacts. If a field is write-only, it must be either
-	// optional or required. Write-only fields cannot be computed.
+	// optional or required. Write-only fields cannot be computed (or sets).
+	// For every top-level write-only field a companion "<field>_version" attribute is generated.
+	// Write-only values are sent on create and on update only when the companion version changes.
 	WriteOnlyFields []string
 
 	// EditOnlyFields lists fields that can be updated only during PATCH request.
@@ -80,6 +97,43 @@ type TFStateHints struct {
 	// Key: Terraform schema field name; Value: API query parameter name.
 	DeleteOnlyParamFields map[string]string
 
+	// ForeignKeyFields maps fields holding ids of other objects to the component they point to.
+	// Key: Terraform schema field name (scalar id or list of ids); Value: snake case component name (e.g. "view_policy").
+	// Referenced objects are checked to exist and to belong to the same tenant at plan time.
+	ForeignKeyFields map[string]string
+
+	// ReferenceFields defines name based alternatives for fields holding ids of other objects.
+	// Key: name attribute (e.g. "tenant_name"); Value: how to resolve it (e.g. {Target: "tenant", Field: "tenant_id"}).
+	// The name attribute is added to the schema as optional and conflicting with the id field
+	// (exactly one of them is required if the id field is required). Before create, read and update
+	// the name is resolved to the id of the referenced object; the id is kept in state.
+	// Name attributes are Terraform only and never sent to the API.
+	ReferenceFields map[string]ReferenceField
+
+	// DeleteRetryOnConflict enables retrying of DELETE requests rejected by VMS because the object
+	// is still in use (e.g. views referencing a policy are destroyed in parallel with the policy).
+	// Applies to the default delete implementation only.
+	DeleteRetryOnConflict *DeleteRetryOnConflict
+
+	// StableComputedFields lists computed fields whose values do not change once the object is created
+	// but that are not detected as such automatically (see UseStateForUnknown in schema generation).
+	// Update plans reuse their prior state values instead of showing "(known after apply)".
+	StableComputedFields []string
+
+	// VolatileFields lists computed fields that change on the backend by themselves
+	// (usage counters, capacities, progress, timestamps of last activity).
+	// On Read their values are kept from the prior state unless it is null, so refresh does not report
+	// "changes made outside of Terraform" for them. Practitioners can extend the list per resource
+	// with the "ignore_remote_changes" attribute.
+	// Volatile fields are never treated as stable computed fields.
+	VolatileFields []string
+
+	// CapacityFields lists integer size fields (capacity, bandwidth) which accept human readable units.
+	// Key: field name; Value: unit of API value ("B" for bytes, "MB" for megabytes, ...).
+	// Such fields are strings in the Terraform schema: either plain number of API units or number with
+	// SI/IEC unit (e.g. "500GB", "10TiB", "1.5PB"). Values denoting the same size do not produce diffs.
+	CapacityFields map[string]string
+
 	// PreserveOrderFields defines fields where the order matters (e.g., for lists instead of sets).
 	PreserveOrderFields []string
 
@@ -92,6 +146,21 @@ type TFStateHints struct {
 	// The key is the attribute name, and the value is the schema definition.
 	AdditionalSchemaAttributes map[string]any
 
+	// Cross-field rules. Each group lists top-level field names; rules are checked at validate time
+	// (see schema_generation.ResourceConfigValidators), unknown values are not checked until known.
+	//
+	// Example:
+	//   ExactlyOneOf: [][]string{{"gid", "uid"}},
+	//
+	// ExactlyOneOf: exactly one field of each group must be configured.
+	ExactlyOneOf [][]string
+	// AtLeastOneOf: at least one field of each group must be configured.
+	AtLeastOneOf [][]string
+	// ConflictsWith: at most one field of each group can be configured.
+	ConflictsWith [][]string
+	// RequiredWith: fields of each group must be configured together (all or none).
+	RequiredWith [][]string
+
 	// CommonValidatorsMapping defines a mapping between resource field names and common validator identifiers.
 	//
 	// Each entry maps a specific resource field (as a string) to a common validator name (also a string or validator definition).
@@ -119,6 +188,20 @@ type TFStateHints struct {
 	//   }
 	// NOTE: All common validators in is in: vastdata/schema_generation/common_modifiers.go
 	CommonModifiersMapping map[string]string
+
+	// CustomTypeMapping defines a mapping between resource field names and common custom type names.
+	//
+	// Custom types compare values semantically, so values which VMS returns in different but equivalent form
+	// (e.g., "7d" returned as "168h", "/a/b/" returned as "/a/b", IP ranges returned in different order)
+	// do not produce diffs.
+	//
+	// Example:
+	//   CustomTypeMapping: map[string]string{
+	//       "grace_period": "duration",
+	//       "ip_ranges":    "ip_range_list",
+	//   }
+	// NOTE: All common custom types are in: vastdata/schema_generation/common_types.go
+	CustomTypeMapping map[string]string
 }
 
 // SchemaReference encapsulates both create and read endpoints for a resource.
@@ -166,6 +249,53 @@ type OpenAPIEndpointRef struct {
 	Path string
 }
 
+// ReferenceField describes resolution of a name attribute to the id of referenced object.
+type ReferenceField struct {
+	// Target is the snake case component name of referenced object (e.g. "tenant").
+	Target string
+	// Field is the id field set from the resolved object (e.g. "tenant_id").
+	Field string
+	// SearchField is the field of referenced object matched against the name. Defaults to "name".
+	SearchField string
+}
+
+// ReferenceSearchField returns the field of referenced object matched against the name.
+func (r ReferenceField) ReferenceSearchField() string {
+	if r.SearchField == "" {
+		return "name"
+	}
+	return r.SearchField
+}
+
+// DeleteRetryOnConflict configures retries of DELETE requests blocked by dependent objects.
+type DeleteRetryOnConflict struct {
+	// Timeout bounds the total time spent on retries unless practitioner configures "timeouts.delete".
+	// Zero means the provider default. The deadline of the request context takes precedence if it is earlier.
+	Timeout time.Duration
+	// Dependents lists fields of other components referencing this resource by id.
+	// Used to report objects that still block deletion once retries are exhausted.
+	Dependents []DependentReference
+}
+
+// DefaultDeleteTimeout bounds retries of DeleteRetryOnConflict when neither Timeout nor "timeouts.delete" is set.
+const DefaultDeleteTimeout = 5 * time.Minute
+
+// DefaultTimeout returns the time spent on retries when "timeouts.delete" is not configured.
+func (r *DeleteRetryOnConflict) DefaultTimeout() time.Duration {
+	if r.Timeout <= 0 {
+		return DefaultDeleteTimeout
+	}
+	return r.Timeout
+}
+
+// DependentReference points to a field of another component holding the id of this resource.
+type DependentReference struct {
+	// Component is the snake case name of the referencing component (e.g. "view").
+	Component string
+	// Field is the referencing field (e.g. "policy_id").
+	Field string
+}
+
 type TFStateHintsForCustom struct {
 	// Description provides a detailed explanation of the resource or data source.
 	Description string
@@ -174,3 +304,16 @@ type TFStateHintsForCustom struct {
 	// SchemaAttributes defines schema attributes to inject into
 	SchemaAttributes map[string]any
 }
+
+// IgnoreRemoteChangesField is the name of resource attribute listing computed fields
+// whose remote changes are ignored on Read (in addition to VolatileFields hint).
+const IgnoreRemoteChangesField = "ignore_remote_changes"
+
+// TimeoutsField is the name of resource attribute configuring operation timeouts ("delete").
+// It is added to resources with DeleteRetryOnConflict hint.
+const TimeoutsField = "timeouts"
+
+// WriteOnlyVersionField returns name of the companion attribute which triggers re-sending write-only field.
+func WriteOnlyVersionField(field string) string {
+	return field + "_version"
+}
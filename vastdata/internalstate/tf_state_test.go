@@ -10,7 +10,10 @@ import (
 
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	pathpkg "github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/stretchr/testify/assert"
@@ -1638,3 +1641,212 @@ func TestTFState_SetOrAdd_OverwriteExistingValue(t *testing.T) {
 }
 
 // NOTE: SetState is simplified in the implementation; skipping write-only persistence behavior tests.
+
+func TestTFState_IdentityRoundTrip(t *testing.T) {
+	ctx := context.Background()
+	schema := rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"id":        rschema.Int64Attribute{Computed: true},
+			"path":      rschema.StringAttribute{Required: true},
+			"tenant_id": rschema.Int64Attribute{Optional: true},
+			"protocols": rschema.SetAttribute{ElementType: types.StringType, Optional: true},
+		},
+	}
+	identitySchema := identityschema.Schema{
+		Attributes: map[string]identityschema.Attribute{
+			"path":      identityschema.StringAttribute{RequiredForImport: true},
+			"tenant_id": identityschema.Int64Attribute{OptionalForImport: true},
+			"protocols": identityschema.ListAttribute{ElementType: types.StringType, OptionalForImport: true},
+		},
+	}
+
+	state := NewTFStateMust(map[string]attr.Value{
+		"id":        types.Int64Value(7),
+		"path":      types.StringValue("/a"),
+		"tenant_id": types.Int64Value(3),
+		"protocols": types.SetValueMust(types.StringType, []attr.Value{types.StringValue("NFS")}),
+	}, schema, nil)
+
+	identity := &tfsdk.ResourceIdentity{
+		Schema: identitySchema,
+		Raw:    tftypes.NewValue(identitySchema.Type().TerraformType(ctx), nil),
+	}
+	require.NoError(t, state.SetIdentity(ctx, identity))
+
+	var path types.String
+	require.False(t, identity.GetAttribute(ctx, pathpkg.Root("path"), &path).HasError())
+	assert.Equal(t, "/a", path.ValueString())
+
+	imported := NewTFStateMust(map[string]attr.Value{}, schema, nil)
+	require.NoError(t, imported.FillFromIdentity(identity))
+	assert.Equal(t, "/a", imported.String("path"))
+	assert.Equal(t, int64(3), imported.Int64("tenant_id"))
+	assert.IsType(t, types.Set{}, imported.Get("protocols"))
+	_, hasID := imported.Raw["id"]
+	assert.False(t, hasID)
+}
+
+func TestTFState_FillFromIdentity_Empty(t *testing.T) {
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{"id": rschema.Int64Attribute{Computed: true}}}
+	state := NewTFStateMust(map[string]attr.Value{}, schema, nil)
+	require.Error(t, state.FillFromIdentity(nil))
+}
+
+func writeOnlyTestSchema() rschema.Schema {
+	return rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id":               rschema.Int64Attribute{Computed: true},
+		"name":             rschema.StringAttribute{Required: true},
+		"password":         rschema.StringAttribute{Optional: true, WriteOnly: true, Sensitive: true},
+		"password_version": rschema.Int64Attribute{Optional: true},
+	}}
+}
+
+func writeOnlyTestState(t *testing.T, name string, password attr.Value, version attr.Value) *TFState {
+	return NewTFStateMust(map[string]attr.Value{
+		"id":               types.Int64Value(1),
+		"name":             types.StringValue(name),
+		"password":         password,
+		"password_version": version,
+	}, writeOnlyTestSchema(), &TFStateHints{WriteOnlyFields: []string{"password"}})
+}
+
+func TestTFState_FillWriteOnlyFromConfig(t *testing.T) {
+	ctx := context.Background()
+	schema := writeOnlyTestSchema()
+	config := tfsdk.Config{
+		Schema: schema,
+		Raw: tftypes.NewValue(schema.Type().TerraformType(ctx), map[string]tftypes.Value{
+			"id":               tftypes.NewValue(tftypes.Number, nil),
+			"name":             tftypes.NewValue(tftypes.String, "from-config"),
+			"password":         tftypes.NewValue(tftypes.String, "secret"),
+			"password_version": tftypes.NewValue(tftypes.Number, 1),
+		}),
+	}
+
+	// Plan carries null for write-only attributes.
+	plan := writeOnlyTestState(t, "from-plan", types.StringNull(), types.Int64Value(1))
+	require.NoError(t, plan.FillWriteOnlyFromConfig(config))
+	assert.Equal(t, "secret", plan.String("password"))
+	// Only write-only values are taken from config.
+	assert.Equal(t, "from-plan", plan.String("name"))
+
+	params := plan.GetCreateParams()
+	assert.Equal(t, "secret", params["password"])
+	assert.NotContains(t, params, "password_version")
+}
+
+func TestTFState_DiffFields_WriteOnly(t *testing.T) {
+	tests := []struct {
+		name         string
+		stateVersion attr.Value
+		planVersion  attr.Value
+		planName     string
+		expect       map[string]any
+	}{
+		{
+			name:         "version unchanged",
+			stateVersion: types.Int64Value(1),
+			planVersion:  types.Int64Value(1),
+			planName:     "renamed",
+			expect:       map[string]any{"name": "renamed"},
+		},
+		{
+			name:         "version bumped",
+			stateVersion: types.Int64Value(1),
+			planVersion:  types.Int64Value(2),
+			planName:     "same",
+			expect:       map[string]any{"password": "secret"},
+		},
+		{
+			name:         "version set first time",
+			stateVersion: types.Int64Null(),
+			planVersion:  types.Int64Value(1),
+			planName:     "same",
+			expect:       map[string]any{"password": "secret"},
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state := writeOnlyTestState(t, "same", types.StringNull(), tt.stateVersion)
+			plan := writeOnlyTestState(t, tt.planName, types.StringValue("secret"), tt.planVersion)
+			assert.Equal(t, tt.expect, plan.DiffFields(state, FilterOr, nil, SearchOptional, SearchRequired))
+		})
+	}
+}
+
+func TestTFState_ReferenceFieldsAreTerraformOnly(t *testing.T) {
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id":          rschema.Int64Attribute{Computed: true},
+		"name":        rschema.StringAttribute{Required: true},
+		"tenant_id":   rschema.Int64Attribute{Optional: true, Computed: true},
+		"tenant_name": rschema.StringAttribute{Optional: true, Computed: true},
+	}}
+	hints := &TFStateHints{ReferenceFields: map[string]ReferenceField{
+		"tenant_name": {Target: "tenant", Field: "tenant_id"},
+	}}
+	newState := func(tenantID int64) *TFState {
+		return NewTFStateMust(map[string]attr.Value{
+			"id":          types.Int64Value(1),
+			"name":        types.StringValue("v1"),
+			"tenant_id":   types.Int64Value(tenantID),
+			"tenant_name": types.StringValue(fmt.Sprintf("tenant-%d", tenantID)),
+		}, schema, hints)
+	}
+
+	params := newState(2).GetCreateParams()
+	assert.Equal(t, int64(2), params["tenant_id"])
+	assert.NotContains(t, params, "tenant_name")
+
+	diff := newState(3).DiffFields(newState(2), FilterOr, nil, SearchOptional, SearchRequired)
+	assert.Equal(t, map[string]any{"tenant_id": int64(3)}, diff)
+}
+
+func TestTFState_FillFromReadRecord_VolatileFields(t *testing.T) {
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id":                     rschema.Int64Attribute{Computed: true},
+		"name":                   rschema.StringAttribute{Required: true},
+		"logical_capacity":       rschema.Int64Attribute{Computed: true},
+		"physical_capacity":      rschema.Int64Attribute{Computed: true},
+		"sync_time":              rschema.StringAttribute{Computed: true},
+		"state":                  rschema.StringAttribute{Computed: true},
+		IgnoreRemoteChangesField: rschema.ListAttribute{Optional: true, ElementType: types.StringType},
+	}}
+	hints := &TFStateHints{VolatileFields: []string{"logical_capacity", "physical_capacity"}}
+	record := Record{
+		"id":                int64(1),
+		"name":              "v1",
+		"logical_capacity":  int64(200),
+		"physical_capacity": int64(100),
+		"sync_time":         "2025-01-02T00:00:00Z",
+		"state":             "SYNCED",
+	}
+
+	state := NewTFStateMust(map[string]attr.Value{
+		"id":                types.Int64Value(1),
+		"name":              types.StringValue("v1"),
+		"logical_capacity":  types.Int64Value(10),
+		"physical_capacity": types.Int64Null(),
+		"sync_time":         types.StringValue("2025-01-01T00:00:00Z"),
+		"state":             types.StringValue("PENDING"),
+		IgnoreRemoteChangesField: types.ListValueMust(types.StringType, []attr.Value{
+			types.StringValue("sync_time"),
+		}),
+	}, schema, hints)
+	assert.Equal(t, []string{"logical_capacity", "physical_capacity", "sync_time"}, state.VolatileFields())
+
+	require.NoError(t, state.FillFromReadRecord(record))
+	// Volatile values are kept ...
+	assert.Equal(t, int64(10), state.Int64("logical_capacity"))
+	assert.Equal(t, "2025-01-01T00:00:00Z", state.String("sync_time"))
+	// ... unless they were null.
+	assert.Equal(t, int64(100), state.Int64("physical_capacity"))
+	// Other computed fields are refreshed.
+	assert.Equal(t, "SYNCED", state.String("state"))
+
+	// Create and update responses are applied as is.
+	require.NoError(t, state.FillFromRecord(record))
+	assert.Equal(t, int64(200), state.Int64("logical_capacity"))
+	assert.Equal(t, "2025-01-02T00:00:00Z", state.String("sync_time"))
+
+	assert.NotContains(t, state.GetCreateParams(), IgnoreRemoteChangesField)
+}
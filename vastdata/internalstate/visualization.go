@@ -211,6 +211,18 @@ func simplifyType(t attr.Type) string {
 		return fmt.Sprintf("map<%s>", simplifyType(tt.ElemType))
 	case types.ObjectType:
 		return "object"
+	case OneOfType:
+		return "one of"
+	case apiObjectType:
+		return "object"
+	case JSONStringType:
+		return "json"
+	case CapacityType:
+		return "capacity"
+	case NormalizedStringType:
+		return string(tt.Kind)
+	case IPRangeListType:
+		return "ip ranges"
 	default:
 		return t.String() // fallback
 	}
@@ -293,7 +305,20 @@ func prettyWithMeta(data map[string]attr.Value, meta map[string]attrMeta) string
 		var valStr string
 		skipEntry := false
 
+		if list, ok := val.(apiListValue); ok {
+			// Custom lists are displayed as regular lists.
+			val, _ = list.ToListValue(context.Background())
+		}
+
 		switch v := val.(type) {
+		case apiStringValue:
+			if val.IsNull() || val.IsUnknown() {
+				valStr = "<null>"
+			} else {
+				str, _ := v.ToStringValue(context.Background())
+				valStr = fmt.Sprintf("%q", str.ValueString())
+			}
+
 		case types.String:
 			if val.IsNull() || val.IsUnknown() {
 				valStr = "<null>"
@@ -23,7 +23,8 @@ func (m *KafkaBroker) NewResourceManager(raw map[string]attr.Value, schema any)
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: KafkaBrokerSchemaRef,
+			SchemaRef:      KafkaBrokerSchemaRef,
+			IdentityFields: []string{"name", "tenant_id"},
 		},
 	)}
 }
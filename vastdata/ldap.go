@@ -26,6 +26,8 @@ func (m *Ldap) NewResourceManager(raw map[string]attr.Value, schema any) Resourc
 			SchemaRef:               LdapSchemaRef,
 			NotComputedSchemaFields: []string{"bindpw"},
 			SearchableFields:        []string{"domain_name"},
+			SensitiveFields:         []string{"bindpw"},
+			WriteOnlyFields:         []string{"bindpw"},
 		},
 	)}
 }
@@ -0,0 +1,201 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements MoveState support for resource types renamed since provider 1.x,
+// so that `moved { from = vastdata_administators_managers.x  to = vastdata_administrator_manager.x }`
+// works without re-import (Terraform 1.8+).
+//
+// Translation mirrors the HCL migration tool (migration/migration_script.py):
+// resource type renames, attribute renames and structural changes of SDKv2 blocks
+// (single element block lists become objects, IP range blocks become [start, end] pairs).
+// No API calls are made; subsequent refresh fills in anything that could not be translated.
+
+package provider
+
+import (
+	"context"
+	"encoding/json"
+	"fmt"
+	"strings"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+)
+
+// legacyResourceTypes maps 1.x resource type names to component names in 2.x.
+var legacyResourceTypes = map[string]string{
+	"vastdata_administators_managers": "administrator_manager",
+	"vastdata_administators_roles":    "administrator_role",
+	"vastdata_administators_realms":   "administrator_realm",
+	"vastdata_kafka_brokers":          "kafka_broker",
+	"vastdata_replication_peers":      "replication_peer",
+	"vastdata_s3_replication_peers":   "s3_replication_peer",
+	"vastdata_active_directory2":      "active_directory",
+	"vastdata_non_local_user":         "nonlocal_user",
+	"vastdata_non_local_user_key":     "nonlocal_user_key",
+	"vastdata_non_local_group":        "nonlocal_group",
+	"vastdata_saml":                   "saml_config",
+	"vastdata_blockhost":              "block_host",
+}
+
+// legacyAttributeRenames maps 1.x attribute names to their 2.x names.
+var legacyAttributeRenames = map[string]string{
+	"type_":            "type",
+	"use32bit_fileid":  "use_32bit_fileid",
+	"permissions_list": "permissions",
+}
+
+// legacyAttributeRenamesPerComponent overrides legacyAttributeRenames for specific components.
+var legacyAttributeRenamesPerComponent = map[string]map[string]string{
+	"administrator_manager": {
+		"permissions_list": "permissions_list",
+		"permissions":      "permissions_list",
+	},
+}
+
+const vastProviderAddressSuffix = "vast-data/vastdata"
+
+func (r *Resource) moveStateImpl(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+	managerName := r.managerName
+	if legacyResourceTypes[req.SourceTypeName] != managerName ||
+		!strings.HasSuffix(strings.ToLower(req.SourceProviderAddress), vastProviderAddressSuffix) {
+		// Not ours: leave target state empty so the framework tries other movers.
+		return
+	}
+	if req.SourceRawState == nil || req.SourceRawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("MoveState[%s]: missing source state.", managerName),
+			fmt.Sprintf("Source state of %q is expected in JSON format.", req.SourceTypeName),
+		)
+		return
+	}
+
+	prior := map[string]any{}
+	if err := json.Unmarshal(req.SourceRawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("MoveState[%s]: failed to decode source state.", managerName),
+			err.Error(),
+		)
+		return
+	}
+
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error fetching OpenAPI schema for %q resource.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	tfState := manager.TfState()
+
+	translated := translateLegacyState(managerName, prior, tfState.TypeMap)
+	if err = tfState.FillFromRawState(translated); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("MoveState[%s]: cannot translate %q state.", managerName, req.SourceTypeName),
+			err.Error(),
+		)
+		return
+	}
+	if err = tfState.SetState(ctx, &resp.TargetState); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("MoveState[%s]: error setting internalstate.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	if err = tfState.SetIdentity(ctx, resp.TargetIdentity); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("MoveState[%s]: error setting identity.", managerName),
+			err.Error(),
+		)
+	}
+}
+
+// translateLegacyState renames 1.x attributes and adapts their values to the 2.x attribute types.
+func translateLegacyState(managerName string, prior map[string]any, typeMap map[string]attr.Type) map[string]any {
+	out := make(map[string]any, len(prior))
+	for k, v := range prior {
+		name := k
+		if renamed, ok := legacyAttributeRenamesPerComponent[managerName][k]; ok {
+			name = renamed
+		} else if renamed, ok := legacyAttributeRenames[k]; ok {
+			name = renamed
+		}
+		t, ok := typeMap[name]
+		if !ok {
+			// Dropped in 2.x.
+			continue
+		}
+		out[name] = adaptLegacyValue(t, v)
+	}
+	return out
+}
+
+// adaptLegacyValue converts SDKv2 value shapes to the shape expected by the target type:
+//   - block lists with a single element -> object
+//   - {start_ip, end_ip} blocks -> [start, end] pairs
+//   - list of numbers -> comma separated string
+func adaptLegacyValue(t attr.Type, v any) any {
+	if v == nil {
+		return nil
+	}
+	switch tt := t.(type) {
+	case types.ObjectType:
+		if list, ok := v.([]any); ok {
+			if len(list) == 0 {
+				return nil
+			}
+			v = list[0]
+		}
+		obj, ok := v.(map[string]any)
+		if !ok {
+			return v
+		}
+		out := make(map[string]any, len(obj))
+		for k, fieldType := range tt.AttrTypes {
+			if fv, ok := obj[k]; ok {
+				out[k] = adaptLegacyValue(fieldType, fv)
+			}
+		}
+		return out
+	case types.ListType:
+		return adaptLegacyList(tt.ElemType, v)
+	case types.SetType:
+		return adaptLegacyList(tt.ElemType, v)
+	case attr.TypeWithElementType:
+		// Custom list types (e.g. is.IPRangeListType).
+		return adaptLegacyList(tt.ElementType(), v)
+	}
+
+	if t.Equal(types.StringType) {
+		if list, ok := v.([]any); ok {
+			parts := make([]string, 0, len(list))
+			for _, item := range list {
+				parts = append(parts, exportScalarString(item))
+			}
+			return strings.Join(parts, ",")
+		}
+	}
+	return v
+}
+
+func adaptLegacyList(elemType attr.Type, v any) any {
+	list, ok := v.([]any)
+	if !ok {
+		return v
+	}
+	out := make([]any, 0, len(list))
+	for _, item := range list {
+		if block, ok := item.(map[string]any); ok {
+			if _, isPair := elemType.(types.ListType); isPair {
+				if start, ok := block["start_ip"]; ok {
+					out = append(out, []any{start, block["end_ip"]})
+					continue
+				}
+			}
+		}
+		out = append(out, adaptLegacyValue(elemType, item))
+	}
+	return out
+}
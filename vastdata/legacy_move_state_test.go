@@ -0,0 +1,171 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+const legacyProviderAddress = "registry.terraform.io/vast-data/vastdata"
+
+// withLegacyResourceType temporarily maps a 1.x resource type to a component.
+func withLegacyResourceType(t *testing.T, legacyType, managerName string) {
+	prev, had := legacyResourceTypes[legacyType]
+	legacyResourceTypes[legacyType] = managerName
+	t.Cleanup(func() {
+		if had {
+			legacyResourceTypes[legacyType] = prev
+		} else {
+			delete(legacyResourceTypes, legacyType)
+		}
+	})
+}
+
+func findResource(t *testing.T, managerName string) *Resource {
+	for _, f := range GetResourceFactories() {
+		if res := f().(*Resource); res.managerName == managerName {
+			return res
+		}
+	}
+	t.Fatalf("resource %q is not registered", managerName)
+	return nil
+}
+
+func runMoveState(t *testing.T, r *Resource, sourceType, sourceAddress, sourceJSON string) *resource.MoveStateResponse {
+	ctx := context.Background()
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	schema := manager.TfState().Schema.(rschema.Schema)
+
+	movers := r.MoveState(ctx)
+	require.Len(t, movers, 1)
+
+	resp := &resource.MoveStateResponse{TargetState: tfsdk.State{
+		Schema: schema,
+		Raw:    tftypes.NewValue(schema.Type().TerraformType(ctx), nil),
+	}}
+	movers[0].StateMover(ctx, resource.MoveStateRequest{
+		SourceTypeName:        sourceType,
+		SourceProviderAddress: sourceAddress,
+		SourceSchemaVersion:   0,
+		SourceRawState:        &tfprotov6.RawState{JSON: []byte(sourceJSON)},
+	}, resp)
+	return resp
+}
+
+func TestMoveState_LegacyTypesRegistered(t *testing.T) {
+	for legacyType, managerName := range legacyResourceTypes {
+		t.Run(legacyType, func(t *testing.T) {
+			findResource(t, managerName)
+		})
+	}
+}
+
+func TestMoveState_TranslatesLegacyState(t *testing.T) {
+	withLegacyResourceType(t, "vastdata_legacy_test", "test")
+	r := buildTestResourceWithSchema(rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id":               rschema.Int64Attribute{Computed: true},
+		"type":             rschema.StringAttribute{Optional: true},
+		"permissions":      rschema.SetAttribute{ElementType: types.StringType, Optional: true},
+		"use_32bit_fileid": rschema.BoolAttribute{Optional: true},
+		"cnode_ids":        rschema.StringAttribute{Optional: true},
+		"ip_ranges": rschema.ListAttribute{
+			ElementType: types.ListType{ElemType: types.StringType},
+			Optional:    true,
+		},
+		"capacity_limits": rschema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]rschema.Attribute{
+				"soft_limit": rschema.Int64Attribute{Optional: true},
+				"hard_limit": rschema.Int64Attribute{Optional: true},
+			},
+		},
+	}}, nil)
+
+	// Recorded state of a 1.x (SDKv2) resource.
+	legacy := `{
+		"id": "5",
+		"guid": "abc",
+		"type_": "local",
+		"permissions_list": ["view_read", "view_read", "quota_read"],
+		"use32bit_fileid": true,
+		"cnode_ids": [1, 2, 3],
+		"ip_ranges": [{"start_ip": "10.0.0.1", "end_ip": "10.0.0.9"}],
+		"capacity_limits": [{"soft_limit": 10, "hard_limit": 20}]
+	}`
+	resp := runMoveState(t, r, "vastdata_legacy_test", legacyProviderAddress, legacy)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+	require.False(t, resp.TargetState.Raw.IsNull())
+
+	ctx := context.Background()
+	var (
+		id          types.Int64
+		typ         types.String
+		cnodeIDs    types.String
+		fileID      types.Bool
+		permissions []string
+		ipRanges    [][]string
+		softLimit   types.Int64
+	)
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("id"), &id).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("type"), &typ).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("cnode_ids"), &cnodeIDs).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("use_32bit_fileid"), &fileID).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("permissions"), &permissions).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("ip_ranges"), &ipRanges).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("capacity_limits").AtName("soft_limit"), &softLimit).HasError())
+
+	assert.Equal(t, int64(5), id.ValueInt64())
+	assert.Equal(t, "local", typ.ValueString())
+	assert.Equal(t, "1,2,3", cnodeIDs.ValueString())
+	assert.True(t, fileID.ValueBool())
+	assert.ElementsMatch(t, []string{"view_read", "quota_read"}, permissions)
+	assert.Equal(t, [][]string{{"10.0.0.1", "10.0.0.9"}}, ipRanges)
+	assert.Equal(t, int64(10), softLimit.ValueInt64())
+}
+
+func TestMoveState_AdministratorManagerPermissions(t *testing.T) {
+	r := findResource(t, "administrator_manager")
+	resp := runMoveState(t, r, "vastdata_administators_managers", legacyProviderAddress,
+		`{"id": "7", "username": "admin1", "permissions_list": ["create_support"]}`)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var username types.String
+	var permissions []string
+	ctx := context.Background()
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("username"), &username).HasError())
+	require.False(t, resp.TargetState.GetAttribute(ctx, path.Root("permissions_list"), &permissions).HasError())
+	assert.Equal(t, "admin1", username.ValueString())
+	assert.Equal(t, []string{"create_support"}, permissions)
+}
+
+func TestMoveState_IgnoresForeignSources(t *testing.T) {
+	r := findResource(t, "kafka_broker")
+	tests := []struct {
+		name          string
+		sourceType    string
+		sourceAddress string
+	}{
+		{name: "unknown type", sourceType: "vastdata_view", sourceAddress: legacyProviderAddress},
+		{name: "other component", sourceType: "vastdata_replication_peers", sourceAddress: legacyProviderAddress},
+		{name: "other provider", sourceType: "vastdata_kafka_brokers", sourceAddress: "registry.terraform.io/acme/vastdata-fork"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			resp := runMoveState(t, r, tt.sourceType, tt.sourceAddress, `{"id": 1}`)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			assert.True(t, resp.TargetState.Raw.IsNull())
+		})
+	}
+}
@@ -29,6 +29,7 @@ func (m *NonlocalUserKey) NewResourceManager(raw map[string]attr.Value, schema a
 		raw,
 		schema,
 		&is.TFStateHints{
+			IdentityFields:       []string{"access_key", "uid"},
 			Importable:           &notImportable,
 			SchemaRef:            NonlocalUserKeySchemaRef,
 			SensitiveFields:      []string{"secret_key"},
@@ -24,6 +24,17 @@ func (m *ProtectedPath) NewResourceManager(raw map[string]attr.Value, schema any
 		schema,
 		&is.TFStateHints{
 			SchemaRef: ProtectedPathSchemaRef,
+			ForeignKeyFields: map[string]string{
+				"tenant_id":            "tenant",
+				"protection_policy_id": "protection_policy",
+			},
+			VolatileFields: []string{
+				"aggr_phys_estimation", "bw", "eta", "estimated_read_only_time", "inode_count", "logical_size",
+				"physical_size", "progress", "last_restore_point_creation_time", "last_restore_point_time",
+				"last_snapshot_creation_time", "last_uploading_restore_point_logical_size",
+				"last_uploading_restore_point_physical_size", "last_uploading_restore_point_progress",
+				"role_change_eta_sec", "role_change_progress_promil",
+			},
 		},
 	)}
 }
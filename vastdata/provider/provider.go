@@ -5,6 +5,7 @@ package provider
 import (
 	"context"
 	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
@@ -15,7 +16,10 @@ import (
 	"time"
 )
 
-var _ provider.Provider = &VastProvider{}
+var (
+	_ provider.Provider                       = &VastProvider{}
+	_ provider.ProviderWithEphemeralResources = &VastProvider{}
+)
 
 type VastProvider struct {
 	version string
@@ -128,6 +132,7 @@ func (p *VastProvider) Configure(ctx context.Context, req provider.ConfigureRequ
 
 	resp.ResourceData = vmsRest
 	resp.DataSourceData = vmsRest
+	resp.EphemeralResourceData = vmsRest
 }
 
 func (p *VastProvider) Resources(_ context.Context) []func() resource.Resource {
@@ -137,3 +142,7 @@ func (p *VastProvider) Resources(_ context.Context) []func() resource.Resource {
 func (p *VastProvider) DataSources(_ context.Context) []func() datasource.DataSource {
 	return vsd.GetDatasourceFactories()
 }
+
+func (p *VastProvider) EphemeralResources(_ context.Context) []func() ephemeral.EphemeralResource {
+	return vsd.GetEphemeralResourceFactories()
+}
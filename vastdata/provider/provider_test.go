@@ -6,6 +6,7 @@ import (
 	"context"
 	"testing"
 
+	"github.com/hashicorp/terraform-plugin-framework/ephemeral"
 	"github.com/hashicorp/terraform-plugin-framework/provider"
 	"github.com/hashicorp/terraform-plugin-framework/provider/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
@@ -59,6 +60,19 @@ func TestVastProvider_Metadata(t *testing.T) {
 	require.Equal(t, "1.2.3", resp.Version)
 }
 
+func TestVastProvider_EphemeralResources(t *testing.T) {
+	t.Parallel()
+
+	p := &VastProvider{version: "test"}
+	var names []string
+	for _, f := range p.EphemeralResources(context.Background()) {
+		resp := &ephemeral.MetadataResponse{}
+		f().Metadata(context.Background(), ephemeral.MetadataRequest{ProviderTypeName: "vastdata"}, resp)
+		names = append(names, resp.TypeName)
+	}
+	require.ElementsMatch(t, []string{"vastdata_api_token", "vastdata_user_key"}, names)
+}
+
 func TestVastProvider_Configure_Success(t *testing.T) {
 	tests := []struct {
 		name   string
@@ -24,6 +24,24 @@ func (m *QosPolicy) NewResourceManager(raw map[string]attr.Value, schema any) Re
 		schema,
 		&is.TFStateHints{
 			SchemaRef: QosPolicySchemaRef,
+			DeleteRetryOnConflict: &is.DeleteRetryOnConflict{
+				Dependents: []is.DependentReference{{Component: "view", Field: "qos_policy_id"}},
+			},
+			// Bandwidth limits (static_limits and static_total_limits) are in MB (per second).
+			CapacityFields: map[string]string{
+				"max_reads_bw_mbps":    "MB",
+				"max_writes_bw_mbps":   "MB",
+				"min_reads_bw_mbps":    "MB",
+				"min_writes_bw_mbps":   "MB",
+				"burst_reads_bw_mb":    "MB",
+				"burst_writes_bw_mb":   "MB",
+				"burst_reads_loan_mb":  "MB",
+				"burst_writes_loan_mb": "MB",
+				"max_bw_mbps":          "MB",
+				"min_bw_mbps":          "MB",
+				"burst_bw_mb":          "MB",
+				"burst_loan_mb":        "MB",
+			},
 		},
 	)}
 }
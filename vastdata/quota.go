@@ -24,6 +24,25 @@ func (m *Quota) NewResourceManager(raw map[string]attr.Value, schema any) Resour
 		schema,
 		&is.TFStateHints{
 			SchemaRef: QuotaSchemaRef,
+			ForeignKeyFields: map[string]string{
+				"tenant_id": "tenant",
+			},
+			ReferenceFields: map[string]is.ReferenceField{
+				"tenant_name": {Target: "tenant", Field: "tenant_id"},
+			},
+			CapacityFields: map[string]string{
+				"hard_limit": "B",
+				"soft_limit": "B",
+			},
+			CustomTypeMapping: map[string]string{
+				"path":         CustomTypePath,
+				"grace_period": CustomTypeDuration,
+			},
+			VolatileFields: []string{
+				"used_capacity", "used_capacity_tb", "used_effective_capacity", "used_effective_capacity_tb",
+				"used_inodes", "used_limited_capacity", "percent_capacity", "percent_inodes", "num_blocked_users",
+				"num_exceeded_users", "last_user_quotas_update", "time_to_block", "pretty_grace_period_expiration",
+			},
 		},
 	)}
 }
@@ -0,0 +1,194 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements handling of references to other cluster objects:
+//   - plan-time validation: fields listed in ForeignKeyFields hint hold ids of objects managed by
+//     other components (e.g. policy_id → view_policy). When planned values are known and changed,
+//     referenced objects are looked up in VMS so that typos fail the plan instead of the apply.
+//   - name based references: attributes listed in ReferenceFields hint (e.g. tenant_name → tenant_id)
+//     are resolved to ids before create, read and update.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"maps"
+	"net/http"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-log/tflog"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// recordGetter fetches record of the component with the given (snake case) name by id.
+type recordGetter func(ctx context.Context, component string, id any) (Record, error)
+
+// componentGetter returns recordGetter backed by APIs of registered components.
+func componentGetter(rest *VMSRest) recordGetter {
+	return func(ctx context.Context, component string, id any) (Record, error) {
+		for _, c := range allTFComponents {
+			if is.SnakeCaseName(c) == component {
+				return c.API(rest).GetByIdWithContext(ctx, id)
+			}
+		}
+		return nil, fmt.Errorf("unknown component %q", component)
+	}
+}
+
+func (r *Resource) modifyPlanImpl(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	if req.Plan.Raw.IsNull() || r.client == nil {
+		// Destroy plan or provider is not configured yet (unknown provider configuration).
+		return
+	}
+	if len(r.EmptyManager().TfState().Hints.ForeignKeyFields) == 0 {
+		return
+	}
+
+	planTfState := r.NewManager(req.Plan).TfState()
+	var stateTfState *is.TFState
+	if !req.State.Raw.IsNull() {
+		stateTfState = r.NewManager(req.State).TfState()
+	}
+	checkForeignKeys(ctx, componentGetter(r.client), planTfState, stateTfState, &resp.Diagnostics)
+}
+
+// checkForeignKeys verifies that objects referenced by ForeignKeyFields of the plan exist
+// and belong to the planned tenant. Only known values that differ from the prior state are checked.
+func checkForeignKeys(ctx context.Context, get recordGetter, planTfState, stateTfState *is.TFState, diags *diag.Diagnostics) {
+	fks := planTfState.Hints.ForeignKeyFields
+	var tenantID any
+	if planTfState.IsKnownAndNotNull("tenant_id") {
+		tenantID = planTfState.Int64("tenant_id")
+	}
+
+	for _, field := range slices.Sorted(maps.Keys(fks)) {
+		component := fks[field]
+		if !planTfState.IsKnownAndNotNull(field) {
+			continue
+		}
+		if stateTfState != nil && stateTfState.Get(field).Equal(planTfState.Get(field)) {
+			continue
+		}
+
+		for _, ref := range foreignKeyRefs(planTfState, field) {
+			p, id := ref.path, ref.id
+			record, err := get(ctx, component, id)
+			switch {
+			case expectStatusCodes(err, http.StatusNotFound):
+				diags.AddAttributeError(
+					p,
+					"Invalid reference",
+					fmt.Sprintf("%s with id %v does not exist.", component, id),
+				)
+				continue
+			case err != nil:
+				// The check is best effort: do not block the plan on lookup failures.
+				tflog.Warn(ctx, fmt.Sprintf("failed to look up %s with id %v referenced by %q: %s", component, id, field, err))
+				continue
+			}
+
+			if tenantID == nil || component == "tenant" {
+				continue
+			}
+			if refTenantID, ok := record["tenant_id"]; ok && !is.IsNil(refTenantID) && !referencesID(refTenantID, tenantID) {
+				diags.AddAttributeError(
+					p,
+					"Invalid reference",
+					fmt.Sprintf("%s with id %v belongs to tenant %v, but the resource belongs to tenant %v.", component, id, refTenantID, tenantID),
+				)
+			}
+		}
+	}
+}
+
+// foreignKeyRef is an id held by ForeignKeyFields field along with the path of the value holding it.
+type foreignKeyRef struct {
+	path path.Path
+	id   any
+}
+
+// foreignKeyRefs returns known ids of the field. Elements of list and set fields get their own
+// paths so that diagnostics point to the offending element.
+func foreignKeyRefs(tfState *is.TFState, field string) []foreignKeyRef {
+	var (
+		refs []foreignKeyRef
+		root = path.Root(field)
+	)
+	add := func(p path.Path, v attr.Value, t attr.Type) {
+		if v.IsNull() || v.IsUnknown() {
+			return
+		}
+		if id := is.ConvertAttrValueToRaw(v, t); id != nil {
+			refs = append(refs, foreignKeyRef{path: p, id: id})
+		}
+	}
+
+	switch v := tfState.Get(field).(type) {
+	case types.List:
+		elemType := tfState.Type(field).(types.ListType).ElemType
+		for i, e := range v.Elements() {
+			add(root.AtListIndex(i), e, elemType)
+		}
+	case types.Set:
+		elemType := tfState.Type(field).(types.SetType).ElemType
+		for _, e := range v.Elements() {
+			add(root.AtSetValue(e), e, elemType)
+		}
+	default:
+		add(root, v, tfState.Type(field))
+	}
+	return refs
+}
+
+// recordFinder fetches the single record of the component with the given (snake case) name matching query.
+type recordFinder func(ctx context.Context, component string, query params) (Record, error)
+
+// componentFinder returns recordFinder backed by APIs of registered components.
+func componentFinder(rest *VMSRest) recordFinder {
+	return func(ctx context.Context, component string, query params) (Record, error) {
+		for _, c := range allTFComponents {
+			if is.SnakeCaseName(c) == component {
+				return c.API(rest).GetWithContext(ctx, query)
+			}
+		}
+		return nil, fmt.Errorf("unknown component %q", component)
+	}
+}
+
+// resolveReferenceFields sets id fields from known name attributes declared in ReferenceFields hint.
+// Id fields that are already known are left as is. Returns false if any name cannot be resolved.
+func resolveReferenceFields(ctx context.Context, find recordFinder, tfState *is.TFState, dg *diag.Diagnostics) bool {
+	if tfState.Hints == nil {
+		return true
+	}
+	ok := true
+	refs := tfState.Hints.ReferenceFields
+	for _, name := range slices.Sorted(maps.Keys(refs)) {
+		ref := refs[name]
+		if !tfState.IsKnownAndNotNull(name) || tfState.IsKnownAndNotNull(ref.Field) {
+			continue
+		}
+		value := tfState.String(name)
+		tflog.Debug(ctx, fmt.Sprintf("resolving %s %q to %q.", ref.Target, value, ref.Field))
+		record, err := find(ctx, ref.Target, params{ref.ReferenceSearchField(): value})
+		if err == nil && record == nil {
+			err = fmt.Errorf("not found")
+		}
+		if err != nil {
+			dg.AddAttributeError(
+				path.Root(name),
+				"Invalid reference",
+				withRemediation(fmt.Sprintf("cannot resolve %s %q to %q: %s", ref.Target, value, ref.Field, err), err),
+			)
+			ok = false
+			continue
+		}
+		tfState.Set(ref.Field, record.RecordID())
+	}
+	return ok
+}
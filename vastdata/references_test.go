@@ -0,0 +1,297 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"net/http"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/diag"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func referencesTestState(t *testing.T, values map[string]attr.Value) *is.TFState {
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"tenant_id":       rschema.Int64Attribute{Optional: true},
+		"policy_id":       rschema.Int64Attribute{Optional: true},
+		"qos_policy_id":   rschema.Int64Attribute{Optional: true},
+		"policies_ids":    rschema.ListAttribute{Optional: true, ElementType: types.Int64Type},
+		"s3_policies_ids": rschema.SetAttribute{Optional: true, ElementType: types.Int64Type},
+	}}
+	raw := map[string]attr.Value{
+		"tenant_id":       types.Int64Null(),
+		"policy_id":       types.Int64Null(),
+		"qos_policy_id":   types.Int64Null(),
+		"policies_ids":    types.ListNull(types.Int64Type),
+		"s3_policies_ids": types.SetNull(types.Int64Type),
+	}
+	for k, v := range values {
+		raw[k] = v
+	}
+	tfState, err := is.NewTFState(raw, schema, is.SchemaForResource, &is.TFStateHints{
+		ForeignKeyFields: map[string]string{
+			"tenant_id":       "tenant",
+			"policy_id":       "view_policy",
+			"qos_policy_id":   "qos_policy",
+			"policies_ids":    "s3_policy",
+			"s3_policies_ids": "s3_policy",
+		},
+	})
+	require.NoError(t, err)
+	return tfState
+}
+
+// fakeGetter serves recorded records keyed by component name and id.
+type fakeGetter struct {
+	records map[string]map[int64]Record
+	calls   []string
+}
+
+func (f *fakeGetter) get(_ context.Context, component string, id any) (Record, error) {
+	f.calls = append(f.calls, fmt.Sprintf("%s/%v", component, id))
+	if component == "qos_policy" {
+		return nil, fmt.Errorf("connection refused")
+	}
+	record, ok := f.records[component][id.(int64)]
+	if !ok {
+		return nil, &ApiError{StatusCode: http.StatusNotFound, Body: `{"detail": "Not found."}`}
+	}
+	return record, nil
+}
+
+func newFakeGetter() *fakeGetter {
+	return &fakeGetter{records: map[string]map[int64]Record{
+		"tenant": {
+			1: {"id": float64(1), "name": "default"},
+			2: {"id": float64(2), "name": "team-a"},
+		},
+		"view_policy": {
+			10: {"id": float64(10), "name": "default", "tenant_id": float64(1)},
+			11: {"id": float64(11), "name": "shared", "tenant_id": float64(2)},
+		},
+		"s3_policy": {
+			20: {"id": float64(20), "name": "p1", "tenant_id": float64(2)},
+		},
+	}}
+}
+
+func attributeErrorPaths(diags diag.Diagnostics) []string {
+	var paths []string
+	for _, d := range diags {
+		if withPath, ok := d.(diag.DiagnosticWithPath); ok {
+			paths = append(paths, withPath.Path().String())
+		}
+	}
+	return paths
+}
+
+func TestReferences_CheckForeignKeys(t *testing.T) {
+	ctx := context.Background()
+
+	t.Run("valid_references", func(t *testing.T) {
+		getter := newFakeGetter()
+		plan := referencesTestState(t, map[string]attr.Value{
+			"tenant_id":    types.Int64Value(2),
+			"policy_id":    types.Int64Value(11),
+			"policies_ids": types.ListValueMust(types.Int64Type, []attr.Value{types.Int64Value(20)}),
+		})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, getter.get, plan, nil, &diags)
+		require.False(t, diags.HasError(), diags)
+		require.ElementsMatch(t, []string{"tenant/2", "view_policy/11", "s3_policy/20"}, getter.calls)
+	})
+
+	t.Run("missing_and_foreign_tenant_references", func(t *testing.T) {
+		plan := referencesTestState(t, map[string]attr.Value{
+			"tenant_id": types.Int64Value(2),
+			"policy_id": types.Int64Value(10),
+			"policies_ids": types.ListValueMust(types.Int64Type, []attr.Value{
+				types.Int64Value(20), types.Int64Value(21),
+			}),
+		})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, newFakeGetter().get, plan, nil, &diags)
+		require.ElementsMatch(t, []string{"policy_id", "policies_ids[1]"}, attributeErrorPaths(diags))
+		for _, d := range diags {
+			switch d.(diag.DiagnosticWithPath).Path().String() {
+			case "policy_id":
+				require.Contains(t, d.Detail(), "belongs to tenant 1")
+			case "policies_ids[1]":
+				require.Contains(t, d.Detail(), "s3_policy with id 21 does not exist")
+			}
+		}
+	})
+
+	t.Run("set_element_paths", func(t *testing.T) {
+		plan := referencesTestState(t, map[string]attr.Value{
+			"s3_policies_ids": types.SetValueMust(types.Int64Type, []attr.Value{
+				types.Int64Value(20), types.Int64Value(21),
+			}),
+		})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, newFakeGetter().get, plan, nil, &diags)
+		require.Len(t, diags, 1)
+		p := diags[0].(diag.DiagnosticWithPath).Path()
+		require.True(t, p.Equal(path.Root("s3_policies_ids").AtSetValue(types.Int64Value(21))), p.String())
+		require.Contains(t, diags[0].Detail(), "s3_policy with id 21 does not exist")
+	})
+
+	t.Run("missing_tenant", func(t *testing.T) {
+		plan := referencesTestState(t, map[string]attr.Value{"tenant_id": types.Int64Value(3)})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, newFakeGetter().get, plan, nil, &diags)
+		require.Equal(t, []string{"tenant_id"}, attributeErrorPaths(diags))
+	})
+
+	t.Run("unchanged_and_unknown_values_are_not_checked", func(t *testing.T) {
+		getter := newFakeGetter()
+		plan := referencesTestState(t, map[string]attr.Value{
+			"tenant_id": types.Int64Unknown(),
+			"policy_id": types.Int64Value(99),
+		})
+		state := referencesTestState(t, map[string]attr.Value{
+			"tenant_id": types.Int64Value(1),
+			"policy_id": types.Int64Value(99),
+		})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, getter.get, plan, state, &diags)
+		require.False(t, diags.HasError())
+		require.Empty(t, getter.calls)
+	})
+
+	t.Run("lookup_failures_do_not_block_plan", func(t *testing.T) {
+		plan := referencesTestState(t, map[string]attr.Value{"qos_policy_id": types.Int64Value(5)})
+		var diags diag.Diagnostics
+		checkForeignKeys(ctx, newFakeGetter().get, plan, nil, &diags)
+		require.False(t, diags.HasError())
+	})
+}
+
+func TestReferences_ResolveReferenceFields(t *testing.T) {
+	ctx := context.Background()
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"tenant_id":   rschema.Int64Attribute{Optional: true, Computed: true},
+		"tenant_name": rschema.StringAttribute{Optional: true, Computed: true},
+		"user_id":     rschema.Int64Attribute{Optional: true, Computed: true},
+		"username":    rschema.StringAttribute{Optional: true, Computed: true},
+	}}
+	hints := &is.TFStateHints{ReferenceFields: map[string]is.ReferenceField{
+		"tenant_name": {Target: "tenant", Field: "tenant_id"},
+		"username":    {Target: "user", Field: "user_id", SearchField: "name"},
+	}}
+	var queries []string
+	find := func(_ context.Context, component string, query params) (Record, error) {
+		queries = append(queries, fmt.Sprintf("%s%v", component, query))
+		switch {
+		case component == "tenant" && query["name"] == "team-a":
+			return Record{"id": int64(2), "name": "team-a"}, nil
+		case component == "user" && query["name"] == "alice":
+			return Record{"id": int64(7), "name": "alice"}, nil
+		}
+		return nil, &ApiError{StatusCode: http.StatusNotFound, Body: `{"detail": "Not found."}`}
+	}
+	newState := func(values map[string]attr.Value) *is.TFState {
+		raw := map[string]attr.Value{
+			"tenant_id":   types.Int64Unknown(),
+			"tenant_name": types.StringNull(),
+			"user_id":     types.Int64Unknown(),
+			"username":    types.StringNull(),
+		}
+		for k, v := range values {
+			raw[k] = v
+		}
+		return is.NewTFStateMust(raw, schema, hints)
+	}
+
+	t.Run("resolves_names", func(t *testing.T) {
+		queries = nil
+		tfState := newState(map[string]attr.Value{
+			"tenant_name": types.StringValue("team-a"),
+			"username":    types.StringValue("alice"),
+		})
+		var diags diag.Diagnostics
+		require.True(t, resolveReferenceFields(ctx, find, tfState, &diags))
+		require.False(t, diags.HasError())
+		require.Equal(t, int64(2), tfState.Int64("tenant_id"))
+		require.Equal(t, int64(7), tfState.Int64("user_id"))
+		require.Equal(t, []string{"tenant" + fmt.Sprint(params{"name": "team-a"}), "user" + fmt.Sprint(params{"name": "alice"})}, queries)
+	})
+
+	t.Run("known_ids_are_kept", func(t *testing.T) {
+		queries = nil
+		tfState := newState(map[string]attr.Value{
+			"tenant_id":   types.Int64Value(5),
+			"tenant_name": types.StringValue("team-a"),
+		})
+		var diags diag.Diagnostics
+		require.True(t, resolveReferenceFields(ctx, find, tfState, &diags))
+		require.Equal(t, int64(5), tfState.Int64("tenant_id"))
+		require.Empty(t, queries)
+	})
+
+	t.Run("unknown_name", func(t *testing.T) {
+		tfState := newState(map[string]attr.Value{
+			"tenant_name": types.StringValue("team-b"),
+			"username":    types.StringValue("alice"),
+		})
+		var diags diag.Diagnostics
+		require.False(t, resolveReferenceFields(ctx, find, tfState, &diags))
+		require.Equal(t, []string{"tenant_name"}, attributeErrorPaths(diags))
+		require.Contains(t, diags[0].Detail(), `cannot resolve tenant "team-b" to "tenant_id"`)
+		// Other references are still resolved.
+		require.Equal(t, int64(7), tfState.Int64("user_id"))
+	})
+}
+
+func TestReferences_ReferenceFieldsHints(t *testing.T) {
+	ctx := context.Background()
+	for _, f := range allTFComponents {
+		rm, ok := f.(ResourceManager)
+		if !ok {
+			continue
+		}
+		refs := rm.NewResourceManager(nil, nil).TfState().Hints.ReferenceFields
+		if len(refs) == 0 {
+			continue
+		}
+		name := is.SnakeCaseName(f)
+		manager, err := findResource(t, name).ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		schema := manager.TfState().Schema.(rschema.Schema)
+		require.False(t, schema.ValidateImplementation(ctx).HasError())
+		for field, ref := range refs {
+			require.True(t, schema.Attributes[field].IsOptional(), "%s.%s", name, field)
+			require.True(t, schema.Attributes[ref.Field].IsComputed(), "%s.%s", name, ref.Field)
+			findResource(t, ref.Target)
+		}
+	}
+}
+
+func TestReferences_ForeignKeyFieldsHints(t *testing.T) {
+	ctx := context.Background()
+	for _, f := range allTFComponents {
+		rm, ok := f.(ResourceManager)
+		if !ok {
+			continue
+		}
+		fks := rm.NewResourceManager(nil, nil).TfState().Hints.ForeignKeyFields
+		if len(fks) == 0 {
+			continue
+		}
+		name := is.SnakeCaseName(f)
+		manager, err := findResource(t, name).ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		for field, target := range fks {
+			_, ok := manager.TfState().TypeMap[field]
+			require.True(t, ok, "%s: no field %q", name, field)
+			findResource(t, target)
+		}
+	}
+}
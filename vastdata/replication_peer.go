@@ -23,7 +23,12 @@ func (m *ReplicationPeer) NewResourceManager(raw map[string]attr.Value, schema a
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: ReplicationPeersSchemaRef,
+			SchemaRef:       ReplicationPeersSchemaRef,
+			SensitiveFields: []string{"password"},
+			WriteOnlyFields: []string{"password"},
+			// "id" is part of PATCH request schema but never changes.
+			StableComputedFields: []string{"id"},
+			VolatileFields:       []string{"last_heart_beat", "space_left"},
 		},
 	)}
 }
@@ -4,6 +4,7 @@ package provider
 
 import (
 	"context"
+	"encoding/json"
 	"errors"
 	"fmt"
 	"net/http"
@@ -17,6 +18,8 @@ import (
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
 	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
 	"github.com/hashicorp/terraform-plugin-log/tflog"
 	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 	"github.com/vast-data/terraform-provider-vastdata/vastdata/schema_generation"
@@ -36,10 +39,13 @@ func (r *Resource) ManagerWithSchemaOnly(ctx context.Context) (ResourceManager,
 	// Get schema for the resource to create a proper manager
 	emptyManager := r.newManager(nil, nil)
 	hints := emptyManager.TfState().Hints
-	schema, err := schema_generation.GetResourceSchema(ctx, hints)
+	schema, err := schema_generation.GetCachedResourceSchema(ctx, r.managerName, hints)
 	if err != nil {
 		return nil, err
 	}
+	if schema.Version, err = stateSchemaVersion(r.managerName); err != nil {
+		return nil, err
+	}
 	// Create a new manager with the schema and empty Raw filled according to schema types
 	// Build a zeroed attr map matching the schema so TFState has all keys with Null values
 	zeroRaw := make(map[string]attr.Value)
@@ -95,6 +101,39 @@ func (r *Resource) Schema(ctx context.Context, req resource.SchemaRequest, resp
 	})
 }
 
+func (r *Resource) IdentitySchema(ctx context.Context, req resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	withContext(ctx, "IdentitySchema", r.managerName, func(ctx context.Context) {
+		r.identitySchemaImpl(ctx, req, resp)
+	})
+}
+
+func (r *Resource) UpgradeState(ctx context.Context) map[int64]resource.StateUpgrader {
+	upgraders := make(map[int64]resource.StateUpgrader)
+	for _, u := range stateUpgraders[r.managerName] {
+		fromVersion := u.FromVersion
+		upgraders[fromVersion] = resource.StateUpgrader{
+			StateUpgrader: func(ctx context.Context, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+				withContext(ctx, "UpgradeState", r.managerName, func(ctx context.Context) {
+					r.upgradeStateImpl(ctx, fromVersion, req, resp)
+				})
+			},
+		}
+	}
+	return upgraders
+}
+
+func (r *Resource) MoveState(ctx context.Context) []resource.StateMover {
+	return []resource.StateMover{
+		{
+			StateMover: func(ctx context.Context, req resource.MoveStateRequest, resp *resource.MoveStateResponse) {
+				withContext(ctx, "MoveState", r.managerName, func(ctx context.Context) {
+					r.moveStateImpl(ctx, req, resp)
+				})
+			},
+		},
+	}
+}
+
 func (r *Resource) Configure(ctx context.Context, req resource.ConfigureRequest, resp *resource.ConfigureResponse) {
 	withContext(ctx, "Configure", r.managerName, func(ctx context.Context) {
 		r.configureImpl(ctx, req, resp)
@@ -138,10 +177,47 @@ func (r *Resource) ValidateConfig(ctx context.Context, req resource.ValidateConf
 
 }
 
+func (r *Resource) ConfigValidators(_ context.Context) []resource.ConfigValidator {
+	return schema_generation.ResourceConfigValidators(r.EmptyManager().TfState().Hints)
+}
+
+func (r *Resource) ModifyPlan(ctx context.Context, req resource.ModifyPlanRequest, resp *resource.ModifyPlanResponse) {
+	withContext(ctx, "ModifyPlan", r.managerName, func(ctx context.Context) {
+		r.modifyPlanImpl(ctx, req, resp)
+	})
+}
+
 // ----------------------------------------
 
-func (r *Resource) metadataImpl(_ context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
+func (r *Resource) metadataImpl(ctx context.Context, req resource.MetadataRequest, resp *resource.MetadataResponse) {
 	resp.TypeName = fmt.Sprintf("%s_%s", req.ProviderTypeName, r.managerName)
+	// Identity derived from ImportFields (e.g. name, path) may be editable in place.
+	// Identity of other resources (e.g. "id") is stable and changes are reported by Terraform.
+	if manager, err := r.ManagerWithSchemaOnly(ctx); err == nil {
+		resourceSchema := manager.TfState().Schema.(rschema.Schema)
+		resp.ResourceBehavior.MutableIdentity = schema_generation.IsMutableIdentity(&resourceSchema, manager.TfState().Hints)
+	}
+}
+
+func (r *Resource) identitySchemaImpl(ctx context.Context, _ resource.IdentitySchemaRequest, resp *resource.IdentitySchemaResponse) {
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error fetching OpenAPI schema for %q resource.", r.managerName),
+			err.Error(),
+		)
+		return
+	}
+	resourceSchema := manager.TfState().Schema.(rschema.Schema)
+	identitySchema, err := schema_generation.GetIdentitySchema(&resourceSchema, manager.TfState().Hints)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error building identity schema for %q resource.", r.managerName),
+			err.Error(),
+		)
+		return
+	}
+	resp.IdentitySchema = *identitySchema
 }
 
 func (r *Resource) schemaImpl(ctx context.Context, _ resource.SchemaRequest, resp *resource.SchemaResponse) {
@@ -164,6 +240,58 @@ func (r *Resource) configureImpl(_ context.Context, req resource.ConfigureReques
 	r.client = req.ProviderData.(*VMSRest)
 }
 
+func (r *Resource) upgradeStateImpl(ctx context.Context, fromVersion int64, req resource.UpgradeStateRequest, resp *resource.UpgradeStateResponse) {
+	managerName := r.managerName
+	if req.RawState == nil || req.RawState.JSON == nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("UpgradeState[%s]: missing prior state.", managerName),
+			"Prior state is expected in JSON format.",
+		)
+		return
+	}
+
+	prior := map[string]any{}
+	if err := json.Unmarshal(req.RawState.JSON, &prior); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("UpgradeState[%s]: failed to decode prior state.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	if err := is.ApplyStateUpgrades(prior, stateUpgraders[managerName], fromVersion); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("UpgradeState[%s]: failed to upgrade state from version %d.", managerName, fromVersion),
+			err.Error(),
+		)
+		return
+	}
+
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	if err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error fetching OpenAPI schema for %q resource.", managerName),
+			err.Error(),
+		)
+		return
+	}
+	tfState := manager.TfState()
+	if err = tfState.FillFromRawState(prior); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("UpgradeState[%s]: prior state is not compatible with the current schema.", managerName),
+			err.Error(),
+		)
+		return
+	}
+
+	resp.State.Raw = tftypes.NewValue(resp.State.Schema.Type().TerraformType(ctx), nil)
+	if err = tfState.SetState(ctx, &resp.State); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("UpgradeState[%s]: error setting internalstate.", managerName),
+			err.Error(),
+		)
+	}
+}
+
 func (r *Resource) importStateImpl(ctx context.Context, req resource.ImportStateRequest, resp *resource.ImportStateResponse) {
 	var (
 		rest        = r.client
@@ -206,20 +334,30 @@ func (r *Resource) importStateImpl(ctx context.Context, req resource.ImportState
 		// Use default import implementation
 		tflog.Debug(ctx, fmt.Sprintf("ImportState[%s]: use default import implementation.", managerName))
 		importID := req.ID
-		if strings.TrimSpace(importID) == "" {
+		switch {
+		case strings.TrimSpace(importID) == "" && req.Identity != nil && !req.Identity.Raw.IsNull():
+			// Typed import via `import { identity = {...} }` block (Terraform 1.12+).
+			if err := tfState.FillFromIdentity(req.Identity); err != nil {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("ImportState[%s]: invalid import identity.", managerName),
+					err.Error(),
+				)
+				return
+			}
+		case strings.TrimSpace(importID) == "":
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("ImportState[%s]: missing import ID.", managerName),
-				fmt.Sprintf("An import ID or key=value list is required for importing the %q resource.", managerName),
-			)
-			return
-		}
-
-		if err := parseImportId(importID, tfState); err != nil {
-			resp.Diagnostics.AddError(
-				fmt.Sprintf("ImportState[%s]: invalid import ID.", managerName),
-				err.Error(),
+				fmt.Sprintf("An import ID, key=value list or identity is required for importing the %q resource.", managerName),
 			)
 			return
+		default:
+			if err := parseImportId(importID, tfState); err != nil {
+				resp.Diagnostics.AddError(
+					fmt.Sprintf("ImportState[%s]: invalid import ID.", managerName),
+					err.Error(),
+				)
+				return
+			}
 		}
 	}
 
@@ -231,6 +369,10 @@ func (r *Resource) importStateImpl(ctx context.Context, req resource.ImportState
 		return
 	}
 
+	if !resolveReferenceFields(ctx, componentFinder(rest), tfState, &resp.Diagnostics) {
+		return
+	}
+
 	// Before reading, allow resource to prepare read (same as in readImpl)
 	if prep, ok := manager.(PrepareReadResource); ok {
 		tflog.Debug(ctx, fmt.Sprintf("PrepareReadResource[%s]: do.", managerName))
@@ -309,6 +451,13 @@ func (r *Resource) importStateImpl(ctx context.Context, req resource.ImportState
 		)
 		return
 	}
+	if err = tfState.SetIdentity(ctx, resp.Identity); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("ImportState[%s]: failed to set identity.", managerName),
+			err.Error(),
+		)
+		return
+	}
 
 	if imp, ok := manager.(AfterImportResourceState); ok {
 		tflog.Debug(ctx, fmt.Sprintf("AfterImportResourceState[%s]: do.", managerName))
@@ -353,6 +502,18 @@ func (r *Resource) createImpl(ctx context.Context, req resource.CreateRequest, r
 		}
 	)
 
+	if err = tfState.FillWriteOnlyFromConfig(req.Config); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Create[%s]: error reading write-only attributes.", managerName),
+			err.Error(),
+		)
+		return
+	}
+
+	if !resolveReferenceFields(ctx, componentFinder(rest), tfState, &resp.Diagnostics) {
+		return
+	}
+
 	if !r.checkNonEmptyFields(ctx, manager, &resp.Diagnostics) {
 		return
 	}
@@ -430,9 +591,11 @@ func (r *Resource) createImpl(ctx context.Context, req resource.CreateRequest, r
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics(
+			&resp.Diagnostics,
 			fmt.Sprintf("error creating %q resource.", managerName),
-			err.Error(),
+			err,
+			tfState.TypeMap,
 		)
 		return
 	}
@@ -496,6 +659,13 @@ func (r *Resource) createImpl(ctx context.Context, req resource.CreateRequest, r
 		)
 		return
 	}
+	if err = tfState.SetIdentity(ctx, resp.Identity); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("error setting identity for %q resource.", managerName),
+			err.Error(),
+		)
+		return
+	}
 }
 
 func (r *Resource) readImpl(ctx context.Context, req resource.ReadRequest, resp *resource.ReadResponse) {
@@ -508,6 +678,10 @@ func (r *Resource) readImpl(ctx context.Context, req resource.ReadRequest, resp
 		err         error
 	)
 
+	if !resolveReferenceFields(ctx, componentFinder(rest), tfState, &resp.Diagnostics) {
+		return
+	}
+
 	if imp, ok := manager.(PrepareReadResource); ok {
 		tflog.Debug(ctx, fmt.Sprintf("PrepareReadResource[%s]: do.", managerName))
 		if err = imp.PrepareReadResource(ctx, rest); err != nil {
@@ -546,9 +720,11 @@ func (r *Resource) readImpl(ctx context.Context, req resource.ReadRequest, resp
 			)
 			resp.State.RemoveResource(ctx)
 		} else {
-			resp.Diagnostics.AddError(
+			addApiErrorDiagnostics(
+				&resp.Diagnostics,
 				fmt.Sprintf("Read[%s]", managerName),
-				err.Error(),
+				err,
+				tfState.TypeMap,
 			)
 			tflog.Error(ctx, fmt.Sprintf("Read[%s]: error reading resource: %s", managerName, err))
 		}
@@ -563,7 +739,7 @@ func (r *Resource) readImpl(ctx context.Context, req resource.ReadRequest, resp
 
 		// In particular scenarios we might want to populate all internalstate in custom handler.
 		// In this case we might want to return nil to avoid this population.
-		if err = tfState.FillFromRecord(record.(Record)); err != nil {
+		if err = tfState.FillFromReadRecord(record.(Record)); err != nil {
 			resp.Diagnostics.AddError(
 				fmt.Sprintf("Read[%s] error filling resource.", managerName),
 				err.Error(),
@@ -592,6 +768,13 @@ func (r *Resource) readImpl(ctx context.Context, req resource.ReadRequest, resp
 		)
 		return
 	}
+	if err = tfState.SetIdentity(ctx, resp.Identity); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Read[%s]: error setting identity.", managerName),
+			err.Error(),
+		)
+		return
+	}
 
 }
 
@@ -608,6 +791,18 @@ func (r *Resource) updateImpl(ctx context.Context, req resource.UpdateRequest, r
 		err         error
 	)
 
+	if err = planTfState.FillWriteOnlyFromConfig(req.Config); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Update[%s]: error reading write-only attributes.", managerName),
+			err.Error(),
+		)
+		return
+	}
+
+	if !resolveReferenceFields(ctx, componentFinder(rest), planTfState, &resp.Diagnostics) {
+		return
+	}
+
 	if imp, ok := stateManger.(PrepareUpdateResource); ok {
 		tflog.Debug(ctx, fmt.Sprintf("PrepareUpdateResource[%s]: do.", managerName))
 		if err = imp.PrepareUpdateResource(ctx, planManager.(PrepareUpdateResource), rest); err != nil {
@@ -661,9 +856,11 @@ func (r *Resource) updateImpl(ctx context.Context, req resource.UpdateRequest, r
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics(
+			&resp.Diagnostics,
 			fmt.Sprintf("Update[%s].", managerName),
-			err.Error(),
+			err,
+			planTfState.TypeMap,
 		)
 		return
 	}
@@ -708,6 +905,13 @@ func (r *Resource) updateImpl(ctx context.Context, req resource.UpdateRequest, r
 		)
 		return
 	}
+	if err = tfState.SetIdentity(ctx, resp.Identity); err != nil {
+		resp.Diagnostics.AddError(
+			fmt.Sprintf("Update[%s]: error setting identity.", managerName),
+			err.Error(),
+		)
+		return
+	}
 }
 
 func (r *Resource) deleteImpl(ctx context.Context, req resource.DeleteRequest, resp *resource.DeleteResponse) {
@@ -749,9 +953,11 @@ func (r *Resource) deleteImpl(ctx context.Context, req resource.DeleteRequest, r
 	}
 
 	if err != nil {
-		resp.Diagnostics.AddError(
+		addApiErrorDiagnostics(
+			&resp.Diagnostics,
 			fmt.Sprintf("Delete[%s]", managerName),
-			err.Error(),
+			err,
+			tfState.TypeMap,
 		)
 		return
 	}
@@ -842,23 +1048,11 @@ func parseAndApplyCompositeImport(importID string, fields []string, tfState *is.
 		if !tfState.HasAttribute(f) {
 			return fmt.Errorf("field %q is not present in the schema", f)
 		}
-		t := tfState.Type(f)
-		switch {
-		case t.Equal(types.Int64Type):
-			n, perr := strconv.ParseInt(val, 10, 64)
-			if perr != nil {
-				return fmt.Errorf("invalid int64 for %q: %v", f, perr)
-			}
-			set(f, types.Int64Value(n))
-		case t.Equal(types.StringType):
-			set(f, types.StringValue(val))
-		case t.Equal(types.BoolType):
-			bv := strings.EqualFold(val, "true") || val == "1"
-			set(f, types.BoolValue(bv))
-		default:
-			// fallback: attempt passthrough as string
-			set(f, types.StringValue(val))
+		v, err := importAttrValue(tfState.Type(f), val)
+		if err != nil {
+			return fmt.Errorf("field %q: %w", f, err)
 		}
+		set(f, v)
 	}
 	return nil
 }
@@ -886,7 +1080,7 @@ func (r *Resource) deleteRecordBySearchParams(ctx context.Context, manager Resou
 		tfState     = manager.TfState()
 		api         = manager.API(rest)
 	)
-	return deleteRecordBySearchParams(ctx, api, tfState, managerName, op)
+	return deleteRecordBySearchParams(ctx, rest, api, tfState, managerName, op)
 }
 
 func (r *Resource) checkNonEmptyFields(ctx context.Context, manager ResourceManager, dg *diag.Diagnostics) bool {
@@ -981,6 +1175,45 @@ func (r *Resource) checkIntegrity(
 	}
 }
 
+// importAttrValue converts value of import ID segment into value of attribute type t.
+// Custom types are built from their underlying scalar type (e.g. capacity and normalized
+// path are strings), so they keep their semantics. Other types can only be set via import identity.
+func importAttrValue(t attr.Type, val string) (attr.Value, error) {
+	ctx := context.Background()
+	var (
+		v     attr.Value
+		diags diag.Diagnostics
+	)
+	switch tt := t.(type) {
+	case basetypes.StringTypable:
+		v, diags = tt.ValueFromString(ctx, types.StringValue(val))
+	case basetypes.Int64Typable:
+		n, err := strconv.ParseInt(val, 10, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", val, err)
+		}
+		v, diags = tt.ValueFromInt64(ctx, types.Int64Value(n))
+	case basetypes.Float64Typable:
+		f, err := strconv.ParseFloat(val, 64)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", val, err)
+		}
+		v, diags = tt.ValueFromFloat64(ctx, types.Float64Value(f))
+	case basetypes.BoolTypable:
+		b, err := strconv.ParseBool(val)
+		if err != nil {
+			return nil, fmt.Errorf("invalid value %q: %w", val, err)
+		}
+		v, diags = tt.ValueFromBool(ctx, types.BoolValue(b))
+	default:
+		return nil, fmt.Errorf("type %s cannot be set from import ID; use import identity instead", t)
+	}
+	if diags.HasError() {
+		return nil, fmt.Errorf("invalid value %q: %s", val, diags.Errors())
+	}
+	return v, nil
+}
+
 // parseImportId parses the import ID into the TFState attributes.
 func parseImportId(importID string, tfState *is.TFState) error {
 	// Use default import implementation
@@ -1008,23 +1241,11 @@ func parseImportId(importID string, tfState *is.TFState) error {
 			if !tfState.HasAttribute(key) {
 				return fmt.Errorf("field %q is not present in the resource schema", key)
 			}
-			t := tfState.Type(key)
-			switch {
-			case t.Equal(types.Int64Type):
-				n, convErr := strconv.ParseInt(val, 10, 64)
-				if convErr != nil {
-					return fmt.Errorf("field %q contains invalid value %q: %w", key, val, convErr)
-				}
-				tfState.SetOrAdd(key, types.Int64Value(n))
-			case t.Equal(types.BoolType):
-				bv := strings.EqualFold(val, "true") || val == "1"
-				tfState.SetOrAdd(key, types.BoolValue(bv))
-			case t.Equal(types.StringType):
-				tfState.SetOrAdd(key, types.StringValue(val))
-			default:
-				// store as string for unsupported types
-				tfState.SetOrAdd(key, types.StringValue(val))
+			v, err := importAttrValue(tfState.Type(key), val)
+			if err != nil {
+				return fmt.Errorf("field %q: %w", key, err)
 			}
+			tfState.SetOrAdd(key, v)
 		}
 	} else if hints != nil && len(hints.ImportFields) > 0 && strings.Contains(importID, "|") {
 		// Ordered values mode via hints
@@ -1048,9 +1269,6 @@ func parseImportId(importID string, tfState *is.TFState) error {
 			tfState.SetOrAdd(idField, types.Int64Value(idInt64))
 		} else if idType.Equal(types.StringType) {
 			tfState.SetOrAdd(idField, types.StringValue(importID))
-		} else if idType.Equal(types.BoolType) {
-			bv := strings.EqualFold(importID, "true") || importID == "1"
-			tfState.SetOrAdd(idField, types.BoolValue(bv))
 		} else {
 			return fmt.Errorf("field %q is not present in the resource schema", idField)
 		}
@@ -11,6 +11,8 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/path"
 	"github.com/hashicorp/terraform-plugin-framework/resource"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
 	"github.com/stretchr/testify/assert"
@@ -635,3 +637,453 @@ func TestFillFromRecordIncludingRequired_ComputedOnlyFalse(t *testing.T) {
 	tfName := tf.Get("name").(types.String)
 	require.Equal(t, "should-be-set", tfName.ValueString())
 }
+
+// TestResourceIdentity_AllResources ensures every registered resource exposes a valid identity schema.
+func TestResourceIdentity_AllResources(t *testing.T) {
+	ctx := context.Background()
+	for _, factory := range GetResourceFactories() {
+		r := factory().(*Resource)
+		t.Run(r.managerName, func(t *testing.T) {
+			resp := &resource.IdentitySchemaResponse{}
+			r.IdentitySchema(ctx, resource.IdentitySchemaRequest{}, resp)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+			require.NotEmpty(t, resp.IdentitySchema.Attributes)
+			require.False(t, resp.IdentitySchema.ValidateImplementation(ctx).HasError())
+		})
+	}
+}
+
+func TestResourceIdentity_Mutable(t *testing.T) {
+	ctx := context.Background()
+	for managerName, mutable := range map[string]bool{
+		"view":                 true,  // path and tenant_name can be updated in place
+		"quota":                false, // identity is "id" assigned by VMS
+		"s3_policy_attachment": true,  // s3_policy_id is updated in place
+		"folder_read_only":     false, // identity fields require replace
+	} {
+		t.Run(managerName, func(t *testing.T) {
+			resp := &resource.MetadataResponse{}
+			findResource(t, managerName).Metadata(ctx, resource.MetadataRequest{ProviderTypeName: "vastdata"}, resp)
+			assert.Equal(t, mutable, resp.ResourceBehavior.MutableIdentity)
+		})
+	}
+}
+
+func TestImportAttrValue_CustomTypes(t *testing.T) {
+	capacity := is.NewCapacityType("B")
+	v, err := importAttrValue(capacity, "10TiB")
+	require.NoError(t, err)
+	assert.Equal(t, is.NewCapacityValue(capacity, "10TiB"), v)
+
+	v, err = importAttrValue(types.Float64Type, "1.5")
+	require.NoError(t, err)
+	assert.Equal(t, types.Float64Value(1.5), v)
+
+	_, err = importAttrValue(types.Int64Type, "x")
+	require.Error(t, err)
+	_, err = importAttrValue(types.ListType{ElemType: types.StringType}, "a")
+	require.ErrorContains(t, err, "use import identity instead")
+}
+
+func TestParseImportId_View(t *testing.T) {
+	ctx := context.Background()
+	for _, importID := range []string{"path=/a/,tenant_name=t", "/a/|t"} {
+		t.Run(importID, func(t *testing.T) {
+			manager, err := findResource(t, "view").ManagerWithSchemaOnly(ctx)
+			require.NoError(t, err)
+			tfState := manager.TfState()
+			require.NoError(t, parseImportId(importID, tfState))
+
+			// path keeps its normalized string type.
+			_, ok := tfState.Get("path").(is.NormalizedStringValue)
+			require.True(t, ok, "%T", tfState.Get("path"))
+			assert.Equal(t, "/a/", tfState.String("path"))
+			assert.Equal(t, "t", tfState.String("tenant_name"))
+		})
+	}
+}
+
+func TestParseImportId_StrictBool(t *testing.T) {
+	schema := rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"id":      rschema.Int64Attribute{Optional: true, Computed: true},
+			"enabled": rschema.BoolAttribute{Optional: true},
+		},
+	}
+	tfState := is.NewTFStateMust(map[string]attr.Value{}, schema, nil)
+	require.NoError(t, parseImportId("id=1,enabled=true", tfState))
+	assert.True(t, tfState.Bool("enabled"))
+
+	tfState = is.NewTFStateMust(map[string]attr.Value{}, schema, nil)
+	require.Error(t, parseImportId("id=1,enabled=yes", tfState))
+}
+
+// TestWriteOnly_HintsMatchSchema guards against WriteOnlyFields hints naming attributes absent from the schema.
+func TestWriteOnly_HintsMatchSchema(t *testing.T) {
+	ctx := context.Background()
+	for _, factory := range GetResourceFactories() {
+		r := factory().(*Resource)
+		manager, err := r.ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		schema := manager.TfState().Schema.(rschema.Schema)
+		for _, field := range manager.TfState().Hints.WriteOnlyFields {
+			attribute, ok := schema.Attributes[field]
+			if assert.True(t, ok, "resource %s: write-only field %q is not in schema", r.managerName, field) {
+				assert.True(t, attribute.IsWriteOnly(), "resource %s: %q", r.managerName, field)
+			}
+		}
+	}
+}
+
+func TestWriteOnly_SecretAttributes(t *testing.T) {
+	ctx := context.Background()
+	tests := []struct {
+		managerName string
+		field       string
+	}{
+		{managerName: "active_directory", field: "bindpw"},
+		{managerName: "ldap", field: "bindpw"},
+		{managerName: "replication_peer", field: "password"},
+		{managerName: "s3_replication_peer", field: "secret_key"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.managerName, func(t *testing.T) {
+			manager, err := findResource(t, tt.managerName).ManagerWithSchemaOnly(ctx)
+			require.NoError(t, err)
+			schema := manager.TfState().Schema.(rschema.Schema)
+			require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+			secret, ok := schema.Attributes[tt.field]
+			require.True(t, ok)
+			assert.True(t, secret.IsWriteOnly())
+			assert.True(t, secret.IsSensitive())
+			assert.False(t, secret.IsComputed())
+
+			version, ok := schema.Attributes[is.WriteOnlyVersionField(tt.field)]
+			require.True(t, ok)
+			assert.True(t, version.IsOptional())
+		})
+	}
+}
+
+func TestVolatileFields_Hints(t *testing.T) {
+	ctx := context.Background()
+	for _, f := range allTFComponents {
+		rm, ok := f.(ResourceManager)
+		if !ok {
+			continue
+		}
+		volatile := rm.NewResourceManager(nil, nil).TfState().Hints.VolatileFields
+		if len(volatile) == 0 {
+			continue
+		}
+		name := is.SnakeCaseName(f)
+		t.Run(name, func(t *testing.T) {
+			manager, err := findResource(t, name).ManagerWithSchemaOnly(ctx)
+			require.NoError(t, err)
+			schema := manager.TfState().Schema.(rschema.Schema)
+			for _, field := range volatile {
+				a, ok := schema.Attributes[field]
+				require.True(t, ok, "no field %q", field)
+				assert.True(t, a.IsComputed(), "field %q is not computed", field)
+			}
+			ignore, ok := schema.Attributes[is.IgnoreRemoteChangesField]
+			require.True(t, ok)
+			assert.True(t, ignore.IsOptional())
+		})
+	}
+}
+
+func TestStableComputed_ViewSchema(t *testing.T) {
+	manager, err := findResource(t, "view").ManagerWithSchemaOnly(context.Background())
+	require.NoError(t, err)
+	attrs := manager.TfState().Schema.(rschema.Schema).Attributes
+
+	useStateForUnknown := func(name string) bool {
+		for _, m := range attrs[name].(rschema.Int64Attribute).PlanModifiers {
+			if m.Description(context.Background()) == int64planmodifier.UseStateForUnknown().Description(context.Background()) {
+				return true
+			}
+		}
+		return false
+	}
+	// Read-only and not updatable.
+	assert.True(t, useStateForUnknown("id"))
+	// Optional, but absent from PATCH request schema.
+	assert.True(t, useStateForUnknown("cluster_id"))
+	// Volatile.
+	assert.False(t, useStateForUnknown("logical_capacity"))
+	// Resolved from tenant_name.
+	assert.False(t, useStateForUnknown("tenant_id"))
+}
+
+func TestPropertyNames_ProtectionPolicyFrames(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "protection_policy").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	tfstate := manager.TfState()
+	schema := tfstate.Schema.(rschema.Schema)
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+	frames := schema.Attributes["frames"].(rschema.ListNestedAttribute)
+	// Required by POST schema, shape comes from response model.
+	assert.True(t, frames.IsRequired())
+	for _, name := range []string{"every", "start_at", "keep_local", "keep_remote"} {
+		assert.Contains(t, frames.NestedObject.Attributes, name)
+	}
+
+	// Keys are renamed back to API names in request body ...
+	tfstate.Set("name", "policy")
+	tfstate.Set("frames", []any{map[string]any{"every": "1D", "start_at": "2025-07-27 20:10:35", "keep_local": "2D"}})
+	body := tfstate.GetCreateParams()
+	assert.Equal(t, []any{map[string]any{"every": "1D", "start-at": "2025-07-27 20:10:35", "keep-local": "2D"}}, body["frames"])
+
+	// ... and sanitized in response.
+	require.NoError(t, tfstate.FillFromRecordIncludingRequired(is.Record{
+		"frames": []any{map[string]any{"every": "2D", "start-at": "2025-07-28 00:00:00", "keep-local": "4D", "keep-remote": "8D"}},
+	}, true))
+	frame := tfstate.TfList("frames").Elements()[0].(is.RenamedObjectValue).Attributes()
+	assert.Equal(t, types.StringValue("2025-07-28 00:00:00"), frame["start_at"])
+	assert.Equal(t, types.StringValue("8D"), frame["keep_remote"])
+
+	dsManager, err := (&Datasource{newManager: (&ProtectionPolicy{}).NewDatasourceManager, managerName: "protection_policy"}).ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	dsFrames := dsManager.TfState().Schema.(dschema.Schema).Attributes["frames"].(dschema.ListNestedAttribute)
+	assert.Contains(t, dsFrames.NestedObject.Attributes, "start_at")
+}
+
+func TestJSONString_ViewPolicyFreeFormObjects(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "view_policy").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	tfstate := manager.TfState()
+	schema := tfstate.Schema.(rschema.Schema)
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+	acl := schema.Attributes["s3_object_acl"].(rschema.StringAttribute)
+	assert.Equal(t, is.JSONStringType{}, acl.CustomType)
+	assert.True(t, acl.IsOptional())
+
+	// JSON strings are decoded in request body ...
+	tfstate.Set("name", "policy")
+	tfstate.Set("s3_object_acl", is.NewJSONStringValue(`{"owner": "u1", "grants": [{"permission": "READ"}]}`))
+	body := tfstate.GetCreateParams()
+	assert.Equal(t, map[string]any{"owner": "u1", "grants": []any{map[string]any{"permission": "READ"}}}, body["s3_object_acl"])
+
+	// ... and encoded from response.
+	require.NoError(t, tfstate.FillFromRecord(is.Record{"remote_mapping": map[string]any{"vip": "10.0.0.1", "id": 1}}))
+	assert.Equal(t, is.NewJSONStringValue(`{"id":1,"vip":"10.0.0.1"}`), tfstate.Get("remote_mapping"))
+}
+
+func TestCapacity_QuotaLimits(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "quota").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	tfstate := manager.TfState()
+	schema := tfstate.Schema.(rschema.Schema)
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+	hardLimit := schema.Attributes["hard_limit"].(rschema.StringAttribute)
+	assert.Equal(t, is.NewCapacityType("B"), hardLimit.CustomType)
+	userQuotas := schema.Attributes["user_quotas"].(rschema.SetNestedAttribute)
+	assert.IsType(t, rschema.StringAttribute{}, userQuotas.NestedObject.Attributes["hard_limit"])
+
+	// Units are converted to bytes in request body ...
+	tfstate.Set("name", "quota")
+	tfstate.Set("path", "/quota")
+	tfstate.Set("hard_limit", "10TiB")
+	tfstate.Set("soft_limit", "1099511627776")
+	body := tfstate.GetCreateParams()
+	assert.Equal(t, int64(10<<40), body["hard_limit"])
+	assert.Equal(t, int64(1<<40), body["soft_limit"])
+
+	// ... and prior state with plain numbers keeps decoding.
+	require.NoError(t, tfstate.FillFromRawState(map[string]any{"hard_limit": float64(1 << 40)}))
+	equal, diags := tfstate.Get("hard_limit").(is.CapacityValue).StringSemanticEquals(ctx, is.NewCapacityValue(is.NewCapacityType("B"), "1TiB"))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+}
+
+func TestCapacity_QoSLimitsBounds(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "qos_policy").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	schema := manager.TfState().Schema.(rschema.Schema)
+	staticLimits := schema.Attributes["static_limits"].(rschema.SingleNestedAttribute)
+	maxReads := staticLimits.Attributes["max_reads_bw_mbps"].(rschema.StringAttribute)
+	require.NotEmpty(t, maxReads.Validators)
+
+	validate := func(value string) bool {
+		capacity := is.NewCapacityValue(is.NewCapacityType("MB"), value)
+		for _, v := range maxReads.Validators {
+			resp := &validator.StringResponse{}
+			v.ValidateString(ctx, validator.StringRequest{
+				Path:        path.Root("static_limits").AtName("max_reads_bw_mbps"),
+				ConfigValue: capacity.StringValue,
+			}, resp)
+			if resp.Diagnostics.HasError() {
+				return false
+			}
+		}
+		return true
+	}
+	// OpenAPI minimum 0 is checked after conversion to MB.
+	assert.True(t, validate("1GB"))
+	assert.True(t, validate("0"))
+	assert.False(t, validate("-1MB"))
+	assert.False(t, validate("-1048576"))
+}
+
+func TestCustomType_SemanticEquality(t *testing.T) {
+	ctx := context.Background()
+
+	viewManager, err := findResource(t, "view").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	viewSchema := viewManager.TfState().Schema.(rschema.Schema)
+	require.False(t, viewSchema.ValidateImplementation(ctx).HasError())
+	assert.Equal(t, is.NewPathType(), viewSchema.Attributes["path"].(rschema.StringAttribute).CustomType)
+	assert.Equal(t, is.NewDurationType(), viewSchema.Attributes["max_retention_period"].(rschema.StringAttribute).CustomType)
+	// Typed getters accept custom string types.
+	viewManager.TfState().Set("path", "/view/")
+	assert.Equal(t, "/view/", viewManager.TfState().String("path"))
+
+	quotaManager, err := findResource(t, "quota").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	quotaState := quotaManager.TfState()
+	require.NoError(t, quotaState.FillFromRawState(map[string]any{"grace_period": "02:00:00"}))
+	equal, diags := quotaState.Get("grace_period").(is.NormalizedStringValue).StringSemanticEquals(
+		ctx, is.NewNormalizedStringValue(is.NewDurationType(), "120m"),
+	)
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+
+	vipPoolManager, err := findResource(t, "vip_pool").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	vipPoolState := vipPoolManager.TfState()
+	vipPoolSchema := vipPoolState.Schema.(rschema.Schema)
+	require.False(t, vipPoolSchema.ValidateImplementation(ctx).HasError())
+	ipRanges := vipPoolSchema.Attributes["ip_ranges"].(rschema.ListAttribute)
+	assert.Equal(t, is.NewIPRangeListType(), ipRanges.CustomType)
+
+	// Ranges are sent as configured; response in different order is semantically equal.
+	configured := []any{[]any{"10.0.0.1", "10.0.0.9"}, []any{"10.0.1.1", "10.0.1.5"}}
+	vipPoolState.Set("ip_ranges", configured)
+	assert.Equal(t, configured, vipPoolState.ToSlice("ip_ranges"))
+	prior := vipPoolState.Get("ip_ranges").(is.IPRangeListValue)
+	require.NoError(t, vipPoolState.FillFromRawState(map[string]any{
+		"ip_ranges": []any{[]any{"10.0.1.1", "10.0.1.5"}, []any{"10.0.0.1", "10.0.0.9"}},
+	}))
+	equal, diags = prior.ListSemanticEquals(ctx, vipPoolState.Get("ip_ranges").(is.IPRangeListValue))
+	require.False(t, diags.HasError())
+	assert.True(t, equal)
+}
+
+func TestUnorderedLists_PrimitiveArraysAreSets(t *testing.T) {
+	ctx := context.Background()
+	primitive := func(t attr.Type) bool {
+		return t.Equal(types.StringType) || t.Equal(types.Int64Type) || t.Equal(types.Float64Type) || t.Equal(types.BoolType)
+	}
+	var check func(t *testing.T, attrs map[string]rschema.Attribute, hints *is.TFStateHints, prefix string)
+	check = func(t *testing.T, attrs map[string]rschema.Attribute, hints *is.TFStateHints, prefix string) {
+		for name, a := range attrs {
+			switch att := a.(type) {
+			case rschema.ListAttribute:
+				if name == is.IgnoreRemoteChangesField || !primitive(att.ElementType) {
+					continue
+				}
+				assert.Contains(t, hints.PreserveOrderFields, name, "%s%s is a list but not marked order-preserving", prefix, name)
+			case rschema.NestedAttribute:
+				nested := make(map[string]rschema.Attribute)
+				for k, v := range att.GetNestedObject().GetAttributes() {
+					nested[k] = v.(rschema.Attribute)
+				}
+				check(t, nested, hints, prefix+name+".")
+			}
+		}
+	}
+	for _, factory := range GetResourceFactories() {
+		r := factory().(*Resource)
+		t.Run(r.managerName, func(t *testing.T) {
+			manager, err := r.ManagerWithSchemaOnly(ctx)
+			require.NoError(t, err)
+			tfstate := manager.TfState()
+			hints := tfstate.Hints
+			if hints == nil {
+				hints = &is.TFStateHints{}
+			}
+			check(t, tfstate.Schema.(rschema.Schema).Attributes, hints, "")
+		})
+	}
+}
+
+func TestUnorderedLists_ReorderedResponse(t *testing.T) {
+	ctx := context.Background()
+	read := func(record Record) *is.TFState {
+		manager, err := findResource(t, "view_policy").ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		require.NoError(t, manager.TfState().FillFromReadRecord(record))
+		return manager.TfState()
+	}
+
+	prior := read(Record{
+		"nfs_read_write": []any{"10.0.0.1", "10.0.0.2", "host-a"},
+		"protocols":      []any{"NFS", "SMB"},
+		"vip_pools":      []any{float64(1), float64(2)},
+	})
+	current := read(Record{
+		"nfs_read_write": []any{"host-a", "10.0.0.2", "10.0.0.1"},
+		"protocols":      []any{"SMB", "NFS"},
+		"vip_pools":      []any{float64(2), float64(1)},
+	})
+
+	for _, field := range []string{"nfs_read_write", "protocols", "vip_pools"} {
+		assert.IsType(t, types.Set{}, current.Get(field), field)
+		assert.True(t, prior.Get(field).Equal(current.Get(field)), field)
+	}
+	// Nothing to update either.
+	assert.Empty(t, current.DiffFields(prior, is.FilterOr, nil, is.SearchOptional, is.SearchRequired))
+}
+
+func TestConfigValidators_MigratedComponents(t *testing.T) {
+	ctx := context.Background()
+	// s3_policy_attachment allows neither gid nor uid, as the former hand-coded check did.
+	attachment := findResource(t, "s3_policy_attachment")
+	assert.Equal(t, [][]string{{"gid", "uid"}}, attachment.EmptyManager().TfState().Hints.ConflictsWith)
+	assert.Empty(t, attachment.EmptyManager().TfState().Hints.ExactlyOneOf)
+	userCopy := findResource(t, "user_copy")
+	assert.Equal(t, [][]string{{"tenant_id", "user_ids"}}, userCopy.EmptyManager().TfState().Hints.ExactlyOneOf)
+	for _, r := range []*Resource{attachment, userCopy} {
+		assert.Len(t, r.ConfigValidators(ctx), 1, r.managerName)
+		_, handCoded := r.EmptyManager().(ValidateResourceConfig)
+		assert.False(t, handCoded, r.managerName)
+	}
+
+	for _, name := range []string{"encryption_group_control", "tenant_encryption_group_control"} {
+		manager, err := findResource(t, name).ManagerWithSchemaOnly(ctx)
+		require.NoError(t, err)
+		action := manager.TfState().Schema.(rschema.Schema).Attributes["action"].(rschema.StringAttribute)
+		require.Len(t, action.Validators, 1, name)
+		assert.Contains(t, action.Validators[0].Description(ctx), "rotate_key", name)
+	}
+}
+
+func TestDocumentation_EnumsAndNestedDescriptions(t *testing.T) {
+	ctx := context.Background()
+	manager, err := findResource(t, "view").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	schema := manager.TfState().Schema.(rschema.Schema)
+
+	protocols := schema.Attributes["protocols"]
+	assert.Contains(t, protocols.GetMarkdownDescription(), "Allowed values: `NFS`, `SMB`, `NFS4`")
+	assert.NotContains(t, protocols.GetDescription(), "Allowed values")
+
+	bucketLogging := schema.Attributes["bucket_logging"].(rschema.SingleNestedAttribute)
+	keyFormat := bucketLogging.Attributes["key_format"]
+	assert.NotEmpty(t, keyFormat.GetDescription())
+	assert.Contains(t, keyFormat.GetMarkdownDescription(), "Allowed values: `SIMPLE_PREFIX`")
+
+	qosManager, err := findResource(t, "qos_policy").ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	qosSchema := qosManager.TfState().Schema.(rschema.Schema)
+	assert.Contains(t, qosSchema.Attributes["io_size_bytes"].GetMarkdownDescription(), "Unit: bytes.")
+}
@@ -24,6 +24,10 @@ func (m *S3LifeCycleRule) NewResourceManager(raw map[string]attr.Value, schema a
 		schema,
 		&is.TFStateHints{
 			SchemaRef: S3LifeCycleRuleSchemaRef,
+			ForeignKeyFields: map[string]string{
+				"view_id": "view",
+			},
+			DefaultSchemaFields: []string{"object_age_attr"},
 		},
 	)}
 }
@@ -26,6 +26,12 @@ func (m *S3Policy) NewResourceManager(raw map[string]attr.Value, schema any) Res
 			SchemaRef:            S3PolicySchemaRef,
 			EditOnlyFields:       []string{"enabled"},
 			OptionalSchemaFields: []string{"enabled"},
+			ForeignKeyFields: map[string]string{
+				"tenant_id": "tenant",
+			},
+			ReferenceFields: map[string]is.ReferenceField{
+				"tenant_name": {Target: "tenant", Field: "tenant_id"},
+			},
 		},
 	)}
 }
@@ -23,7 +23,9 @@ func (m *S3ReplicationPeer) NewResourceManager(raw map[string]attr.Value, schema
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: S3ReplicationPeerSchemaRef,
+			SchemaRef:       S3ReplicationPeerSchemaRef,
+			SensitiveFields: []string{"secret_key"},
+			WriteOnlyFields: []string{"secret_key"},
 		},
 	)}
 }
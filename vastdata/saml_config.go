@@ -28,7 +28,8 @@ func (m *SamlConfig) NewResourceManager(raw map[string]attr.Value, schema any) R
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: SamlConfigSchemaRef,
+			SchemaRef:      SamlConfigSchemaRef,
+			IdentityFields: []string{"vms_id", "idp_name"},
 			AdditionalSchemaAttributes: map[string]any{
 				"vms_id": rschema.Int64Attribute{
 					Required:    true,
@@ -0,0 +1,41 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+const (
+	CustomTypeDuration    = "duration"      // e.g., 7d == 168h == 7 00:00:00
+	CustomTypePath        = "path"          // e.g., /a/b/ == /a/b
+	CustomTypeIPRangeList = "ip_range_list" // [[start, end], ...] in any order
+)
+
+// Common custom types for string attributes (e.g., "path", "grace_period")
+var commonStringTypes = map[string]basetypes.StringTypable{
+	CustomTypeDuration: is.NewDurationType(),
+	CustomTypePath:     is.NewPathType(),
+}
+
+// Common custom types for list attributes (e.g., "ip_ranges")
+var commonListTypes = map[string]basetypes.ListTypable{
+	CustomTypeIPRangeList: is.NewIPRangeListType(),
+}
+
+// customStringType returns custom type mapped to string field via CustomTypeMapping hint (nil if none).
+func customStringType(name string, hints *TFStateHints) basetypes.StringTypable {
+	if hints == nil {
+		return nil
+	}
+	return commonStringTypes[hints.CustomTypeMapping[name]]
+}
+
+// customListType returns custom type mapped to list field via CustomTypeMapping hint (nil if none).
+func customListType(name string, hints *TFStateHints) basetypes.ListTypable {
+	if hints == nil {
+		return nil
+	}
+	return commonListTypes[hints.CustomTypeMapping[name]]
+}
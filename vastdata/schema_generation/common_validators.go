@@ -122,3 +122,40 @@ func validateHHMMSS(s string) error {
 func GracePeriodValidator() validator.String {
 	return gracePeriodValidator{}
 }
+
+// -------------------------
+// DurationValidator
+// -------------------------
+
+type durationValidator struct{}
+
+func (v durationValidator) Description(ctx context.Context) string {
+	return "String must be a positive Go duration (e.g. 30s, 10m, 1h)."
+}
+
+func (v durationValidator) MarkdownDescription(ctx context.Context) string {
+	return "String must be a positive Go duration (e.g. `30s`, `10m`, `1h`)."
+}
+
+func (v durationValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	val := req.ConfigValue
+	if val.IsNull() || val.IsUnknown() {
+		return
+	}
+
+	d, err := time.ParseDuration(val.ValueString())
+	if err == nil && d <= 0 {
+		err = fmt.Errorf("duration must be positive")
+	}
+	if err != nil {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Duration",
+			fmt.Sprintf("Expected a duration like 30s or 10m, got %q: %s", val.ValueString(), err),
+		)
+	}
+}
+
+func DurationValidator() validator.String {
+	return durationValidator{}
+}
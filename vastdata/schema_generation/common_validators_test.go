@@ -57,3 +57,43 @@ func TestGracePeriodValidator(t *testing.T) {
 		})
 	}
 }
+
+func TestDurationValidator(t *testing.T) {
+	t.Parallel()
+
+	validatorFn := DurationValidator()
+
+	cases := []struct {
+		name     string
+		input    string
+		wantErr  bool
+		errorMsg string
+	}{
+		{"Seconds", "30s", false, ""},
+		{"Compound", "1h30m", false, ""},
+
+		{"Missing Unit", "10", true, "missing unit"},
+		{"Zero", "0s", true, "must be positive"},
+		{"Negative", "-5m", true, "must be positive"},
+		{"Days Not Supported", "1d", true, "unknown unit"},
+	}
+
+	for _, tc := range cases {
+		t.Run(tc.name, func(t *testing.T) {
+			req := validator.StringRequest{
+				ConfigValue: types.StringValue(tc.input),
+				Path:        path.Root("timeouts").AtName("delete"),
+			}
+
+			var resp validator.StringResponse
+			validatorFn.ValidateString(context.Background(), req, &resp)
+
+			if tc.wantErr {
+				require.True(t, resp.Diagnostics.HasError(), "expected error but got none")
+				require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), tc.errorMsg)
+			} else {
+				require.False(t, resp.Diagnostics.HasError(), "expected no error but got one")
+			}
+		})
+	}
+}
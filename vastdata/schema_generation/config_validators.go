@@ -0,0 +1,65 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Cross-field rules declared in hints (ExactlyOneOf, AtLeastOneOf, ConflictsWith, RequiredWith)
+// are translated into framework config validators, so violations are reported at validate time
+// with attribute paths instead of failing during apply.
+
+package schema_generation
+
+import (
+	"github.com/hashicorp/terraform-plugin-framework-validators/datasourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/resourcevalidator"
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+)
+
+// ResourceConfigValidators returns resource config validators for cross-field rules from hints.
+func ResourceConfigValidators(hints *TFStateHints) []resource.ConfigValidator {
+	if hints == nil {
+		return nil
+	}
+	var validators []resource.ConfigValidator
+	for _, fields := range hints.ExactlyOneOf {
+		validators = append(validators, resourcevalidator.ExactlyOneOf(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.AtLeastOneOf {
+		validators = append(validators, resourcevalidator.AtLeastOneOf(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.ConflictsWith {
+		validators = append(validators, resourcevalidator.Conflicting(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.RequiredWith {
+		validators = append(validators, resourcevalidator.RequiredTogether(rootExpressions(fields)...))
+	}
+	return validators
+}
+
+// DatasourceConfigValidators returns data source config validators for cross-field rules from hints.
+func DatasourceConfigValidators(hints *TFStateHints) []datasource.ConfigValidator {
+	if hints == nil {
+		return nil
+	}
+	var validators []datasource.ConfigValidator
+	for _, fields := range hints.ExactlyOneOf {
+		validators = append(validators, datasourcevalidator.ExactlyOneOf(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.AtLeastOneOf {
+		validators = append(validators, datasourcevalidator.AtLeastOneOf(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.ConflictsWith {
+		validators = append(validators, datasourcevalidator.Conflicting(rootExpressions(fields)...))
+	}
+	for _, fields := range hints.RequiredWith {
+		validators = append(validators, datasourcevalidator.RequiredTogether(rootExpressions(fields)...))
+	}
+	return validators
+}
+
+func rootExpressions(fields []string) []path.Expression {
+	expressions := make([]path.Expression, 0, len(fields))
+	for _, field := range fields {
+		expressions = append(expressions, path.MatchRoot(field))
+	}
+	return expressions
+}
@@ -0,0 +1,57 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+)
+
+func TestConfigValidators_FromHints(t *testing.T) {
+	require.Nil(t, ResourceConfigValidators(nil))
+	require.Nil(t, DatasourceConfigValidators(&TFStateHints{}))
+
+	hints := &TFStateHints{
+		ExactlyOneOf:  [][]string{{"gid", "uid"}},
+		AtLeastOneOf:  [][]string{{"name", "path"}},
+		ConflictsWith: [][]string{{"name", "id"}},
+		RequiredWith:  [][]string{{"user", "password"}},
+	}
+	require.Len(t, ResourceConfigValidators(hints), 4)
+	require.Len(t, DatasourceConfigValidators(hints), 4)
+}
+
+func TestConfigValidators_ExactlyOneOf(t *testing.T) {
+	ctx := context.Background()
+	schema := rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"gid": rschema.Int64Attribute{Optional: true},
+		"uid": rschema.Int64Attribute{Optional: true},
+	}}
+	validators := ResourceConfigValidators(&TFStateHints{ExactlyOneOf: [][]string{{"gid", "uid"}}})
+	require.Len(t, validators, 1)
+
+	validate := func(gid, uid any) *resource.ValidateConfigResponse {
+		objType := schema.Type().TerraformType(ctx).(tftypes.Object)
+		config := tfsdk.Config{Schema: schema, Raw: tftypes.NewValue(objType, map[string]tftypes.Value{
+			"gid": tftypes.NewValue(tftypes.Number, gid),
+			"uid": tftypes.NewValue(tftypes.Number, uid),
+		})}
+		resp := &resource.ValidateConfigResponse{}
+		validators[0].ValidateResource(ctx, resource.ValidateConfigRequest{Config: config}, resp)
+		return resp
+	}
+
+	require.False(t, validate(1001, nil).Diagnostics.HasError())
+	require.True(t, validate(nil, nil).Diagnostics.HasError())
+	resp := validate(1001, 1001)
+	require.True(t, resp.Diagnostics.HasError())
+	require.Contains(t, resp.Diagnostics.Errors()[0].Detail(), "gid")
+	// Unknown values are checked once known.
+	require.False(t, validate(tftypes.UnknownValue, nil).Diagnostics.HasError())
+}
@@ -0,0 +1,53 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	dsschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// objectCustomType returns custom type for nested object whose API shape differs from Terraform shape:
+// polymorphic objects (is.OneOfType) and objects with sanitized property names (is.RenamedObjectType).
+// Returns nil for regular objects.
+func objectCustomType(schema *openapi3.Schema, attrTypes map[string]attr.Type) basetypes.ObjectTypable {
+	if oneOf := client.GetOneOf(schema); oneOf != nil {
+		return is.NewOneOfType(attrTypes, oneOf)
+	}
+	if apiNames := client.GetAPINames(schema); len(apiNames) > 0 {
+		return is.NewRenamedObjectType(attrTypes, apiNames)
+	}
+	return nil
+}
+
+// resourceObjectType returns custom type of nested resource object (see objectCustomType).
+// Variants of polymorphic object get exactly-one validators.
+func resourceObjectType(schema *openapi3.Schema, attrs map[string]rschema.Attribute) basetypes.ObjectTypable {
+	if client.GetOneOf(schema) != nil {
+		for name, a := range attrs {
+			if variant, ok := a.(rschema.SingleNestedAttribute); ok {
+				variant.Validators = append(variant.Validators, oneOfVariantValidators(name, attrs)...)
+				attrs[name] = variant
+			}
+		}
+	}
+	return objectCustomType(schema, attributeTypes(attrs))
+}
+
+// datasourceObjectType returns custom type of nested datasource object (see objectCustomType).
+func datasourceObjectType(schema *openapi3.Schema, attrs map[string]dsschema.Attribute) basetypes.ObjectTypable {
+	return objectCustomType(schema, attributeTypes(attrs))
+}
+
+func attributeTypes[A interface{ GetType() attr.Type }](attrs map[string]A) map[string]attr.Type {
+	attrTypes := make(map[string]attr.Type, len(attrs))
+	for name, a := range attrs {
+		attrTypes[name] = a.GetType()
+	}
+	return attrTypes
+}
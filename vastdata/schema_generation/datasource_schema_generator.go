@@ -15,6 +15,7 @@ import (
 	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
 
 var excludeSearchParams = []string{"page", "page_size", "sync", "created", "sync_time"}
@@ -182,7 +183,7 @@ func buildDatasourceAttributesFromMap(
 		if attr == nil {
 			continue
 		}
-		result[name] = attr
+		result[name] = documentDatasourceAttribute(name, attr, schema, hints)
 	}
 	return result
 }
@@ -204,6 +205,7 @@ func buildDatasourceAttribute(
 	switch (*schema.Type)[0] {
 	case openapi3.TypeString:
 		return dschema.StringAttribute{
+			CustomType:          customStringType(name, hints),
 			Required:            entry.Required,
 			Optional:            entry.Optional,
 			Computed:            entry.Computed,
@@ -213,6 +215,19 @@ func buildDatasourceAttribute(
 		}
 
 	case openapi3.TypeInteger:
+		if unit, ok := capacityUnit(name, hints); ok {
+			capacityDesc := capacityDescription(desc)
+			return dschema.StringAttribute{
+				CustomType:          is.NewCapacityType(unit),
+				Required:            entry.Required,
+				Optional:            entry.Optional,
+				Computed:            entry.Computed,
+				Sensitive:           entry.Sensitive,
+				Description:         capacityDesc,
+				MarkdownDescription: capacityDesc,
+			}
+		}
+
 		return dschema.Int64Attribute{
 			Required:            entry.Required,
 			Optional:            entry.Optional,
@@ -248,22 +263,21 @@ func buildDatasourceAttribute(
 			panic("invalid item schema for array")
 		}
 
-		if (*itemSchema.Type)[0] == openapi3.TypeObject && len(itemSchema.Properties) == 0 {
-			return nil // skip empty nested object
-		}
-
 		isOrdered := entry.Ordered
 
-		switch (*itemSchema.Type)[0] {
-		case openapi3.TypeObject:
+		// Free-form object items are JSON strings (see default branch).
+		switch itemType := (*itemSchema.Type)[0]; {
+		case itemType == openapi3.TypeObject && !isAmbiguousObject(itemSchema):
 			nested := make(map[string]*SchemaEntry)
 			addSchemaEntries(itemSchema.Properties, itemSchema.Required, hints, nested, false, false, true, false, false, entry.Ordered)
 			attrs := buildDatasourceAttributesFromMap(ctx, nested, hints)
+			customType := datasourceObjectType(itemSchema, attrs)
 
 			if isOrdered {
 				return dschema.ListNestedAttribute{
 					NestedObject: dschema.NestedAttributeObject{
 						Attributes: attrs,
+						CustomType: customType,
 					},
 					Computed:            true,
 					Description:         entry.Description,
@@ -273,18 +287,22 @@ func buildDatasourceAttribute(
 			return dschema.SetNestedAttribute{
 				NestedObject: dschema.NestedAttributeObject{
 					Attributes: attrs,
+					CustomType: customType,
 				},
 				Computed:            true,
 				Description:         entry.Description,
 				MarkdownDescription: entry.Description,
 			}
 
-		case openapi3.TypeArray:
+		case itemType == openapi3.TypeArray:
 			inner := resolveComposedSchema(resolveAllRefs(itemSchema.Items))
 			innerType := buildAttrTypeFromSchema(inner)
 
-			if isOrdered {
+			// Custom list types compare values semantically, so they are lists even if order does not matter.
+			customType := customListType(name, hints)
+			if isOrdered || customType != nil {
 				return dschema.ListAttribute{
+					CustomType:          customType,
 					ElementType:         types.ListType{ElemType: innerType},
 					Computed:            true,
 					Description:         entry.Description,
@@ -317,22 +335,31 @@ func buildDatasourceAttribute(
 		}
 
 	case openapi3.TypeObject:
-		if schema.AdditionalProperties.Schema != nil {
-			valSchema := resolveComposedSchema(resolveAllRefs(schema.AdditionalProperties.Schema))
-			if valSchema.Type != nil && (*valSchema.Type)[0] == openapi3.TypeObject && len(valSchema.Properties) == 0 {
-				warnWithContext(ctx, fmt.Sprintf("Skipping. Map value schema for %q is empty object", name))
-				return nil
+		if isFreeFormObject(schema) {
+			// Free-form object (including maps declared via additionalProperties) is returned as JSON string.
+			return dschema.StringAttribute{
+				CustomType:          is.JSONStringType{},
+				Required:            entry.Required,
+				Optional:            entry.Optional,
+				Computed:            entry.Computed,
+				Sensitive:           entry.Sensitive,
+				Description:         desc,
+				MarkdownDescription: desc,
 			}
+		}
 
+		if schema.AdditionalProperties.Schema != nil {
+			valSchema := resolveComposedSchema(resolveAllRefs(schema.AdditionalProperties.Schema))
 			valType := buildAttrTypeFromSchema(valSchema)
 
 			if valSchema.Type != nil && (*valSchema.Type)[0] == openapi3.TypeObject && len(valSchema.Properties) > 0 {
 				nested := make(map[string]*SchemaEntry)
 				addSchemaEntries(valSchema.Properties, valSchema.Required, hints, nested, entry.Required, entry.Optional, entry.Computed, entry.WriteOnly, entry.Sensitive, entry.Ordered)
-
+				attrs := buildDatasourceAttributesFromMap(ctx, nested, hints)
 				return dschema.MapNestedAttribute{
 					NestedObject: dschema.NestedAttributeObject{
-						Attributes: buildDatasourceAttributesFromMap(ctx, nested, hints),
+						Attributes: attrs,
+						CustomType: datasourceObjectType(valSchema, attrs),
 					},
 					Required:            entry.Required,
 					Optional:            entry.Optional,
@@ -357,9 +384,10 @@ func buildDatasourceAttribute(
 		if len(schema.Properties) > 0 {
 			nested := make(map[string]*SchemaEntry)
 			addSchemaEntries(schema.Properties, schema.Required, hints, nested, entry.Required, entry.Optional, entry.Computed, entry.WriteOnly, entry.Sensitive, entry.Ordered)
-
+			attrs := buildDatasourceAttributesFromMap(ctx, nested, hints)
 			return dschema.SingleNestedAttribute{
-				Attributes:          buildDatasourceAttributesFromMap(ctx, nested, hints),
+				Attributes:          attrs,
+				CustomType:          datasourceObjectType(schema, attrs),
 				Required:            entry.Required,
 				Optional:            entry.Optional,
 				Computed:            entry.Computed,
@@ -185,7 +185,7 @@ func TestBuildResourceAttribute_EdgeCases(t *testing.T) {
 				Optional: true,
 			},
 			hints:     &internalstate.TFStateHints{},
-			expectNil: true, // Should return nil for empty objects
+			expectNil: false, // Free-form object is JSON string
 		},
 		{
 			name: "array_with_empty_object_items",
@@ -202,7 +202,7 @@ func TestBuildResourceAttribute_EdgeCases(t *testing.T) {
 				Optional: true,
 			},
 			hints:     &internalstate.TFStateHints{},
-			expectNil: true,
+			expectNil: false, // Set of JSON strings
 		},
 	}
 
@@ -0,0 +1,151 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements ephemeral resource schema generation (Terraform 1.10+).
+// Ephemeral schemas are derived from the generated resource schema: attribute names,
+// types, requiredness, sensitivity and validators are preserved while plan modifiers
+// and defaults (which have no meaning for ephemeral resources) are dropped.
+
+package schema_generation
+
+import (
+	"context"
+	"fmt"
+
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// GetEphemeralSchema builds ephemeral resource schema for a component from its resource schema.
+func GetEphemeralSchema(ctx context.Context, hints *TFStateHints) (*eschema.Schema, error) {
+	resourceSchema, err := GetResourceSchema(ctx, hints)
+	if err != nil {
+		return nil, err
+	}
+	attrs, err := buildEphemeralAttributes(resourceSchema.Attributes)
+	if err != nil {
+		return nil, err
+	}
+	// Ephemeral resources are never refreshed nor deleted.
+	delete(attrs, is.IgnoreRemoteChangesField)
+	delete(attrs, is.TimeoutsField)
+	return &eschema.Schema{
+		Attributes:          attrs,
+		Description:         resourceSchema.Description,
+		MarkdownDescription: resourceSchema.MarkdownDescription,
+	}, nil
+}
+
+func buildEphemeralAttributes(in map[string]rschema.Attribute) (map[string]eschema.Attribute, error) {
+	out := make(map[string]eschema.Attribute, len(in))
+	for name, a := range in {
+		converted, err := buildEphemeralAttribute(a)
+		if err != nil {
+			return nil, fmt.Errorf("ephemeral attribute %q: %w", name, err)
+		}
+		out[name] = converted
+	}
+	return out, nil
+}
+
+func buildEphemeralAttribute(a rschema.Attribute) (eschema.Attribute, error) {
+	switch a := a.(type) {
+	case rschema.StringAttribute:
+		return eschema.StringAttribute{
+			Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.Int64Attribute:
+		return eschema.Int64Attribute{
+			Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.Float64Attribute:
+		return eschema.Float64Attribute{
+			Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.NumberAttribute:
+		return eschema.NumberAttribute{
+			Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.BoolAttribute:
+		return eschema.BoolAttribute{
+			Required: a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.ListAttribute:
+		return eschema.ListAttribute{
+			ElementType: a.ElementType,
+			Required:    a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.SetAttribute:
+		return eschema.SetAttribute{
+			ElementType: a.ElementType,
+			Required:    a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.MapAttribute:
+		return eschema.MapAttribute{
+			ElementType: a.ElementType,
+			Required:    a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.SingleNestedAttribute:
+		attrs, err := buildEphemeralAttributes(a.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return eschema.SingleNestedAttribute{
+			Attributes: attrs,
+			Required:   a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.ListNestedAttribute:
+		attrs, err := buildEphemeralAttributes(a.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return eschema.ListNestedAttribute{
+			NestedObject: eschema.NestedAttributeObject{Attributes: attrs},
+			Required:     a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.SetNestedAttribute:
+		attrs, err := buildEphemeralAttributes(a.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return eschema.SetNestedAttribute{
+			NestedObject: eschema.NestedAttributeObject{Attributes: attrs},
+			Required:     a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	case rschema.MapNestedAttribute:
+		attrs, err := buildEphemeralAttributes(a.NestedObject.Attributes)
+		if err != nil {
+			return nil, err
+		}
+		return eschema.MapNestedAttribute{
+			NestedObject: eschema.NestedAttributeObject{Attributes: attrs},
+			Required:     a.Required, Optional: a.Optional, Computed: a.Computed, Sensitive: a.Sensitive,
+			Description: a.Description, MarkdownDescription: a.MarkdownDescription, DeprecationMessage: a.DeprecationMessage,
+			CustomType: a.CustomType, Validators: a.Validators,
+		}, nil
+	default:
+		return nil, fmt.Errorf("unsupported attribute type %T", a)
+	}
+}
@@ -0,0 +1,62 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"testing"
+
+	eschema "github.com/hashicorp/terraform-plugin-framework/ephemeral/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestBuildEphemeralAttributes(t *testing.T) {
+	attrs, err := buildEphemeralAttributes(map[string]rschema.Attribute{
+		"id": rschema.StringAttribute{
+			Computed:      true,
+			PlanModifiers: []planmodifier.String{stringplanmodifier.UseStateForUnknown()},
+		},
+		"owner":      rschema.StringAttribute{Optional: true, Computed: true, Description: "owner"},
+		"user_id":    rschema.Int64Attribute{Required: true},
+		"secret_key": rschema.StringAttribute{Computed: true, Sensitive: true},
+		"tags":       rschema.SetAttribute{ElementType: types.StringType, Optional: true},
+		"limits": rschema.SingleNestedAttribute{
+			Optional: true,
+			Attributes: map[string]rschema.Attribute{
+				"soft": rschema.Int64Attribute{Optional: true},
+			},
+		},
+		"rules": rschema.ListNestedAttribute{
+			Optional: true,
+			NestedObject: rschema.NestedAttributeObject{Attributes: map[string]rschema.Attribute{
+				"name": rschema.StringAttribute{Required: true},
+			}},
+		},
+	})
+	require.NoError(t, err)
+
+	assert.Equal(t, eschema.StringAttribute{Computed: true}, attrs["id"])
+	assert.Equal(t, eschema.StringAttribute{Optional: true, Computed: true, Description: "owner"}, attrs["owner"])
+	assert.True(t, attrs["user_id"].IsRequired())
+	assert.True(t, attrs["secret_key"].IsSensitive())
+	assert.Equal(t, types.SetType{ElemType: types.StringType}, attrs["tags"].GetType())
+
+	limits, ok := attrs["limits"].(eschema.SingleNestedAttribute)
+	require.True(t, ok)
+	assert.Contains(t, limits.Attributes, "soft")
+
+	rules, ok := attrs["rules"].(eschema.ListNestedAttribute)
+	require.True(t, ok)
+	assert.True(t, rules.NestedObject.Attributes["name"].IsRequired())
+}
+
+func TestBuildEphemeralAttributes_Unsupported(t *testing.T) {
+	_, err := buildEphemeralAttributes(map[string]rschema.Attribute{
+		"value": rschema.DynamicAttribute{Optional: true},
+	})
+	require.Error(t, err)
+}
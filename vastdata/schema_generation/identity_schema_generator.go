@@ -0,0 +1,139 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements resource identity schema generation (Terraform 1.12+).
+// Identity attributes are derived from the resource schema using IdentityFields,
+// ImportFields or the "id" attribute (in that order of precedence).
+
+package schema_generation
+
+import (
+	"context"
+	"fmt"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+)
+
+// IdentityFields returns field names forming the resource identity.
+func IdentityFields(hints *TFStateHints) []string {
+	if hints != nil {
+		if len(hints.IdentityFields) > 0 {
+			return hints.IdentityFields
+		}
+		if len(hints.ImportFields) > 0 {
+			return hints.ImportFields
+		}
+	}
+	return []string{"id"}
+}
+
+// GetIdentitySchema builds identity schema for a resource from its generated schema.
+// Only primitive attributes (and lists/sets of primitives) can be part of identity.
+func GetIdentitySchema(resourceSchema *rschema.Schema, hints *TFStateHints) (*identityschema.Schema, error) {
+	attrs := make(map[string]identityschema.Attribute)
+	for i, name := range IdentityFields(hints) {
+		resourceAttr, ok := resourceSchema.Attributes[name]
+		if !ok {
+			continue
+		}
+		required := i == 0
+		identityAttr, err := buildIdentityAttribute(resourceAttr.GetType(), required, resourceAttr.GetDescription())
+		if err != nil {
+			return nil, fmt.Errorf("identity attribute %q: %w", name, err)
+		}
+		attrs[name] = identityAttr
+	}
+	if len(attrs) == 0 {
+		return nil, fmt.Errorf("none of identity fields %v are present in the resource schema", IdentityFields(hints))
+	}
+	return &identityschema.Schema{Attributes: attrs}, nil
+}
+
+func buildIdentityAttribute(t attr.Type, required bool, description string) (identityschema.Attribute, error) {
+	optional := !required
+	switch tt := t.(type) {
+	case types.ListType:
+		return identityschema.ListAttribute{ElementType: tt.ElemType, RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	case types.SetType:
+		// Identity schema has no sets; elements are stored as list.
+		return identityschema.ListAttribute{ElementType: tt.ElemType, RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	case basetypes.StringTypable:
+		if !tt.Equal(types.StringType) {
+			// Custom string types (e.g. is.NormalizedStringType) keep their semantic equality in identity.
+			return identityschema.StringAttribute{CustomType: tt, RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+		}
+	}
+	switch t.String() {
+	case types.StringType.String():
+		return identityschema.StringAttribute{RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	case types.Int64Type.String():
+		return identityschema.Int64Attribute{RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	case types.Float64Type.String():
+		return identityschema.Float64Attribute{RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	case types.BoolType.String():
+		return identityschema.BoolAttribute{RequiredForImport: required, OptionalForImport: optional, Description: description}, nil
+	default:
+		return nil, fmt.Errorf("unsupported identity attribute type %s", t)
+	}
+}
+
+// requiresReplaceDescription is the description shared by RequiresReplace plan modifiers of all attribute types.
+var requiresReplaceDescription = stringplanmodifier.RequiresReplace().Description(context.Background())
+
+// IsMutableIdentity reports whether identity of the resource can change in place, i.e. some identity
+// attribute is configurable and is not replaced on change. Computed only attributes (e.g. "id")
+// are assigned by VMS and never change.
+func IsMutableIdentity(resourceSchema *rschema.Schema, hints *TFStateHints) bool {
+	for _, name := range IdentityFields(hints) {
+		a, ok := resourceSchema.Attributes[name]
+		if !ok || !(a.IsRequired() || a.IsOptional()) {
+			continue
+		}
+		if !requiresReplace(a) {
+			return true
+		}
+	}
+	return false
+}
+
+// requiresReplace reports whether attribute has RequiresReplace plan modifier.
+func requiresReplace(a rschema.Attribute) bool {
+	var descriptions []string
+	collect := func(m interface {
+		Description(context.Context) string
+	}) {
+		descriptions = append(descriptions, m.Description(context.Background()))
+	}
+	switch aa := a.(type) {
+	case rschema.StringAttribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	case rschema.Int64Attribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	case rschema.Float64Attribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	case rschema.BoolAttribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	case rschema.ListAttribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	case rschema.SetAttribute:
+		for _, m := range aa.PlanModifiers {
+			collect(m)
+		}
+	}
+	return slices.Contains(descriptions, requiresReplaceDescription)
+}
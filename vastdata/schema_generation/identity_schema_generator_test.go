@@ -0,0 +1,85 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/resource/identityschema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func testIdentityResourceSchema() *rschema.Schema {
+	return &rschema.Schema{
+		Attributes: map[string]rschema.Attribute{
+			"id":          rschema.Int64Attribute{Computed: true},
+			"path":        rschema.StringAttribute{Required: true},
+			"tenant_name": rschema.StringAttribute{Optional: true, Computed: true},
+			"enabled":     rschema.BoolAttribute{Optional: true},
+			"protocols":   rschema.SetAttribute{ElementType: types.StringType, Optional: true},
+			"share_acl":   rschema.SingleNestedAttribute{Optional: true, Attributes: map[string]rschema.Attribute{}},
+		},
+	}
+}
+
+func TestIdentityFields_Precedence(t *testing.T) {
+	require.Equal(t, []string{"id"}, IdentityFields(nil))
+	require.Equal(t, []string{"id"}, IdentityFields(&TFStateHints{}))
+	require.Equal(t, []string{"path", "tenant_name"}, IdentityFields(&TFStateHints{ImportFields: []string{"path", "tenant_name"}}))
+	require.Equal(t, []string{"name"}, IdentityFields(&TFStateHints{
+		ImportFields:   []string{"path", "tenant_name"},
+		IdentityFields: []string{"name"},
+	}))
+}
+
+func TestGetIdentitySchema_FromImportFields(t *testing.T) {
+	s, err := GetIdentitySchema(testIdentityResourceSchema(), &TFStateHints{ImportFields: []string{"path", "tenant_name"}})
+	require.NoError(t, err)
+	require.Len(t, s.Attributes, 2)
+
+	path, ok := s.Attributes["path"].(identityschema.StringAttribute)
+	require.True(t, ok)
+	require.True(t, path.RequiredForImport)
+
+	tenant, ok := s.Attributes["tenant_name"].(identityschema.StringAttribute)
+	require.True(t, ok)
+	require.True(t, tenant.OptionalForImport)
+
+	require.False(t, s.ValidateImplementation(context.Background()).HasError())
+}
+
+func TestGetIdentitySchema_DefaultsToID(t *testing.T) {
+	s, err := GetIdentitySchema(testIdentityResourceSchema(), &TFStateHints{})
+	require.NoError(t, err)
+	require.IsType(t, identityschema.Int64Attribute{}, s.Attributes["id"])
+}
+
+func TestGetIdentitySchema_SetBecomesList(t *testing.T) {
+	s, err := GetIdentitySchema(testIdentityResourceSchema(), &TFStateHints{IdentityFields: []string{"path", "protocols", "enabled"}})
+	require.NoError(t, err)
+	list, ok := s.Attributes["protocols"].(identityschema.ListAttribute)
+	require.True(t, ok)
+	require.Equal(t, types.StringType, list.ElementType)
+	require.IsType(t, identityschema.BoolAttribute{}, s.Attributes["enabled"])
+}
+
+func TestGetIdentitySchema_Errors(t *testing.T) {
+	_, err := GetIdentitySchema(testIdentityResourceSchema(), &TFStateHints{IdentityFields: []string{"missing"}})
+	require.Error(t, err)
+
+	_, err = GetIdentitySchema(testIdentityResourceSchema(), &TFStateHints{IdentityFields: []string{"share_acl"}})
+	require.Error(t, err)
+}
+
+func TestGetIdentitySchema_CustomStringType(t *testing.T) {
+	resourceSchema := testIdentityResourceSchema()
+	resourceSchema.Attributes["path"] = rschema.StringAttribute{Required: true, CustomType: is.NewPathType()}
+	s, err := GetIdentitySchema(resourceSchema, &TFStateHints{ImportFields: []string{"path"}})
+	require.NoError(t, err)
+	require.Equal(t, is.NewPathType(), s.Attributes["path"].(identityschema.StringAttribute).CustomType)
+	require.False(t, s.ValidateImplementation(context.Background()).HasError())
+}
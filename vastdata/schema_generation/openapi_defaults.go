@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file applies OpenAPI "default" values as Terraform schema defaults.
+// With a schema default the plan shows the actual value instead of "(known after apply)",
+// and removing the attribute from configuration reverts it to the default on the cluster.
+// Only fields listed in DefaultSchemaFields hint get defaults: many OpenAPI defaults are not what
+// the cluster actually sets, and enforcing them would revert server-set values.
+
+package schema_generation
+
+import (
+	"context"
+	"fmt"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/booldefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/float64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64default"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/listdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/objectdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setdefault"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringdefault"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-framework/types/basetypes"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// applyOpenAPIDefaults sets schema defaults of optional attributes listed in DefaultSchemaFields hint
+// from OpenAPI "default" values of corresponding properties. Attributes of single nested objects are
+// matched by dotted path; attributes nested in lists, sets and maps are left as is (defaults are per element there).
+func applyOpenAPIDefaults(ctx context.Context, attrs map[string]rschema.Attribute, props map[string]*openapi3.Schema, hints *TFStateHints) {
+	if hints == nil || len(hints.DefaultSchemaFields) == 0 {
+		return
+	}
+	applyOpenAPIDefaultsAt(ctx, "", attrs, props, hints)
+}
+
+func applyOpenAPIDefaultsAt(ctx context.Context, prefix string, attrs map[string]rschema.Attribute, props map[string]*openapi3.Schema, hints *TFStateHints) {
+	for name, att := range attrs {
+		path := prefix + name
+		prop := resolveComposedSchema(props[name])
+		if prop == nil || !hasDefault(path, att, prop, hints) {
+			if nested, ok := att.(rschema.SingleNestedAttribute); ok && prop != nil {
+				applyOpenAPIDefaultsAt(ctx, path+".", nested.Attributes, propertySchemas(prop.Properties), hints)
+			}
+			continue
+		}
+		withDefault, err := attributeWithDefault(att, prop.Default)
+		if err != nil {
+			warnWithContext(ctx, fmt.Sprintf("Skipping default %v of %q: %s", prop.Default, path, err))
+			continue
+		}
+		attrs[name] = withDefault
+	}
+}
+
+func hasDefault(path string, att rschema.Attribute, prop *openapi3.Schema, hints *TFStateHints) bool {
+	if prop.Default == nil || !att.IsOptional() || att.IsWriteOnly() {
+		return false
+	}
+	// Defaults make attributes computed, which contradicts NotComputedSchemaFields.
+	return contains(hints.DefaultSchemaFields, path) &&
+		!contains(hints.NotComputedSchemaFields, path) &&
+		!contains(hints.ReadOnlyFields, path) &&
+		!contains(hints.EditOnlyFields, path)
+}
+
+// attributeWithDefault returns computed copy of the attribute with static default built from raw OpenAPI value.
+func attributeWithDefault(att rschema.Attribute, raw any) (rschema.Attribute, error) {
+	value, err := is.BuildAttrValueFromAny(att.GetType(), raw)
+	if err != nil {
+		return nil, err
+	}
+	switch a := att.(type) {
+	case rschema.StringAttribute:
+		str, _ := value.(basetypes.StringValuable).ToStringValue(context.Background())
+		a.Computed, a.Default = true, stringdefault.StaticString(str.ValueString())
+		return a, nil
+	case rschema.Int64Attribute:
+		a.Computed, a.Default = true, int64default.StaticInt64(value.(types.Int64).ValueInt64())
+		return a, nil
+	case rschema.Float64Attribute:
+		a.Computed, a.Default = true, float64default.StaticFloat64(value.(types.Float64).ValueFloat64())
+		return a, nil
+	case rschema.BoolAttribute:
+		a.Computed, a.Default = true, booldefault.StaticBool(value.(types.Bool).ValueBool())
+		return a, nil
+	case rschema.ListAttribute:
+		a.Computed, a.Default = true, listdefault.StaticValue(value.(types.List))
+		return a, nil
+	case rschema.SetAttribute:
+		a.Computed, a.Default = true, setdefault.StaticValue(value.(types.Set))
+		return a, nil
+	case rschema.MapAttribute:
+		a.Computed, a.Default = true, mapdefault.StaticValue(value.(types.Map))
+		return a, nil
+	case rschema.SingleNestedAttribute:
+		a.Computed, a.Default = true, objectdefault.StaticValue(value.(types.Object))
+		return a, nil
+	default:
+		return nil, fmt.Errorf("defaults are not supported for %T", att)
+	}
+}
+
+func propertySchemas(props openapi3.Schemas) map[string]*openapi3.Schema {
+	out := make(map[string]*openapi3.Schema, len(props))
+	for name, ref := range props {
+		out[name] = resolveAllRefs(ref)
+	}
+	return out
+}
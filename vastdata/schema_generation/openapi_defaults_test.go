@@ -0,0 +1,116 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/resource/schema/defaults"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+)
+
+func TestApplyOpenAPIDefaults(t *testing.T) {
+	ctx := context.Background()
+	entries := map[string]*SchemaEntry{
+		"enabled": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeBoolean), Default: true},
+			Optional: true,
+		},
+		"object_age_attr": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeString), Default: "M_TIME"},
+			Optional: true,
+		},
+		"protocols": {
+			Prop: &openapi3.Schema{
+				Type:    toTypes(openapi3.TypeArray),
+				Items:   &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString)}},
+				Default: []any{"NFS"},
+			},
+			Optional: true,
+			Ordered:  true,
+		},
+		"static_limits": {
+			Prop: &openapi3.Schema{
+				Type: toTypes(openapi3.TypeObject),
+				Properties: map[string]*openapi3.SchemaRef{
+					"max_iops": {Value: &openapi3.Schema{Type: toTypes(openapi3.TypeInteger), Default: float64(0)}},
+				},
+			},
+			Optional: true,
+		},
+		"name": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeString), Default: "x"},
+			Required: true,
+		},
+		"is_vms_auth_provider": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeBoolean), Default: false},
+			Optional: true,
+		},
+		"page_size": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeInteger), Default: float64(100)},
+			Optional: true,
+		},
+		"bindpw": {
+			Prop:     &openapi3.Schema{Type: toTypes(openapi3.TypeString), Default: ""},
+			Optional: true,
+		},
+	}
+	hints := &TFStateHints{
+		DefaultSchemaFields: []string{
+			"enabled", "object_age_attr", "protocols", "static_limits.max_iops", "name", "page_size", "bindpw",
+		},
+		NotComputedSchemaFields: []string{"bindpw"},
+		ReadOnlyFields:          []string{"page_size"},
+	}
+	attrs := buildResourceAttributesFromMap(ctx, entries, hints)
+	props := make(map[string]*openapi3.Schema)
+	for name, entry := range entries {
+		props[name] = entry.Prop
+	}
+	applyOpenAPIDefaults(ctx, attrs, props, hints)
+
+	enabled := attrs["enabled"].(rschema.BoolAttribute)
+	require.True(t, enabled.Optional)
+	require.True(t, enabled.Computed)
+	require.NotNil(t, enabled.Default)
+	resp := &defaults.BoolResponse{}
+	enabled.Default.DefaultBool(ctx, defaults.BoolRequest{}, resp)
+	require.Equal(t, types.BoolValue(true), resp.PlanValue)
+
+	ageAttr := attrs["object_age_attr"].(rschema.StringAttribute)
+	stringResp := &defaults.StringResponse{}
+	ageAttr.Default.DefaultString(ctx, defaults.StringRequest{}, stringResp)
+	require.Equal(t, types.StringValue("M_TIME"), stringResp.PlanValue)
+
+	protocols := attrs["protocols"].(rschema.ListAttribute)
+	listResp := &defaults.ListResponse{}
+	protocols.Default.DefaultList(ctx, defaults.ListRequest{}, listResp)
+	require.Equal(t, types.ListValueMust(types.StringType, []attr.Value{types.StringValue("NFS")}), listResp.PlanValue)
+
+	maxIops := attrs["static_limits"].(rschema.SingleNestedAttribute).Attributes["max_iops"].(rschema.Int64Attribute)
+	require.True(t, maxIops.Computed)
+	int64Resp := &defaults.Int64Response{}
+	maxIops.Default.DefaultInt64(ctx, defaults.Int64Request{}, int64Resp)
+	require.Equal(t, types.Int64Value(0), int64Resp.PlanValue)
+
+	require.Nil(t, attrs["name"].(rschema.StringAttribute).Default)
+	require.Nil(t, attrs["is_vms_auth_provider"].(rschema.BoolAttribute).Default)
+	require.False(t, attrs["is_vms_auth_provider"].IsComputed())
+	require.Nil(t, attrs["page_size"].(rschema.Int64Attribute).Default)
+	require.Nil(t, attrs["bindpw"].(rschema.StringAttribute).Default)
+	require.False(t, attrs["bindpw"].IsComputed())
+
+	// Fields not listed in DefaultSchemaFields keep no default.
+	attrs = buildResourceAttributesFromMap(ctx, entries, &TFStateHints{})
+	applyOpenAPIDefaults(ctx, attrs, props, &TFStateHints{})
+	require.Nil(t, attrs["enabled"].(rschema.BoolAttribute).Default)
+	require.Nil(t, attrs["static_limits"].(rschema.SingleNestedAttribute).Attributes["max_iops"].(rschema.Int64Attribute).Default)
+
+	schema := rschema.Schema{Attributes: attrs}
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+}
@@ -0,0 +1,190 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file carries OpenAPI documentation into generated attributes: MarkdownDescription is extended
+// with allowed (enum) values and units, and "deprecated" properties get DeprecationMessage,
+// so Terraform warns when they are configured.
+
+package schema_generation
+
+import (
+	"fmt"
+	"strings"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+)
+
+const openAPIDeprecationMessage = "This attribute is deprecated by the VAST API and may be removed in a future release."
+
+// attributeUnit describes unit of a field derived from its name suffix (e.g. "retention_ms").
+type attributeUnit struct {
+	suffix string
+	unit   string
+	// mention is lowercase word which, when present in description, means the unit is already documented.
+	mention string
+}
+
+// Order matters: longer suffixes go first.
+var attributeUnits = []attributeUnit{
+	{suffix: "_per_sec"}, // rates (e.g. "read_iops_per_sec") are described by the name itself
+	{suffix: "_bw_mb", unit: "MB/s", mention: "mb"},
+	{suffix: "_mbps", unit: "MB/s", mention: "mb"},
+	{suffix: "_mb", unit: "MB", mention: "mb"},
+	{suffix: "_tb", unit: "TB", mention: "tb"},
+	{suffix: "_bytes", unit: "bytes", mention: "byte"},
+	{suffix: "_ms", unit: "milliseconds", mention: "milli"},
+	{suffix: "_seconds", unit: "seconds", mention: "second"},
+	{suffix: "_sec", unit: "seconds", mention: "second"},
+	{suffix: "_days", unit: "days", mention: "day"},
+	{suffix: "_percent", unit: "percent", mention: "percent"},
+}
+
+// unitOf returns unit of the field derived from its name. Capacity fields (see TFStateHints.CapacityFields)
+// accept values with units so they are skipped.
+func unitOf(name string, hints *TFStateHints) (attributeUnit, bool) {
+	if _, ok := capacityUnit(name, hints); ok {
+		return attributeUnit{}, false
+	}
+	for _, u := range attributeUnits {
+		if strings.HasSuffix(name, u.suffix) {
+			return u, u.unit != ""
+		}
+	}
+	return attributeUnit{}, false
+}
+
+// enumValues returns allowed values of the property (of array items for arrays).
+func enumValues(schema *openapi3.Schema) []any {
+	if schema == nil {
+		return nil
+	}
+	if len(schema.Enum) == 0 && getSchemaType(schema) == openapi3.TypeArray && schema.Items != nil {
+		if items := resolveComposedSchema(resolveAllRefs(schema.Items)); items != nil {
+			return items.Enum
+		}
+	}
+	return schema.Enum
+}
+
+// markdownDescription extends description with allowed values and unit of the property.
+func markdownDescription(name, desc string, schema *openapi3.Schema, hints *TFStateHints) string {
+	var notes []string
+	if values := enumValues(schema); len(values) > 0 {
+		quoted := make([]string, 0, len(values))
+		for _, v := range values {
+			if v == nil {
+				continue
+			}
+			quoted = append(quoted, fmt.Sprintf("`%v`", v))
+		}
+		if len(quoted) > 0 {
+			notes = append(notes, "Allowed values: "+strings.Join(quoted, ", ")+".")
+		}
+	}
+	if u, ok := unitOf(name, hints); ok && !strings.Contains(strings.ToLower(desc), u.mention) {
+		notes = append(notes, "Unit: "+u.unit+".")
+	}
+	if len(notes) == 0 {
+		return desc
+	}
+	if desc == "" {
+		return strings.Join(notes, " ")
+	}
+	return strings.TrimRight(desc, ". ") + ". " + strings.Join(notes, " ")
+}
+
+// deprecationMessage returns deprecation message for properties marked "deprecated" in OpenAPI schema.
+func deprecationMessage(schema *openapi3.Schema) string {
+	if schema == nil || !schema.Deprecated {
+		return ""
+	}
+	return openAPIDeprecationMessage
+}
+
+// documentResourceAttribute returns copy of the attribute with markdown description and deprecation message
+// built from OpenAPI property.
+func documentResourceAttribute(name string, att rschema.Attribute, schema *openapi3.Schema, hints *TFStateHints) rschema.Attribute {
+	md := markdownDescription(name, att.GetDescription(), schema, hints)
+	deprecation := deprecationMessage(schema)
+	switch a := att.(type) {
+	case rschema.StringAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.Int64Attribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.Float64Attribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.BoolAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.ListAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.SetAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.MapAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.ListNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.SetNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.MapNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case rschema.SingleNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	default:
+		return att
+	}
+}
+
+// documentDatasourceAttribute is documentResourceAttribute for data source attributes.
+func documentDatasourceAttribute(name string, att dschema.Attribute, schema *openapi3.Schema, hints *TFStateHints) dschema.Attribute {
+	md := markdownDescription(name, att.GetDescription(), schema, hints)
+	deprecation := deprecationMessage(schema)
+	switch a := att.(type) {
+	case dschema.StringAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.Int64Attribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.Float64Attribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.BoolAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.ListAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.SetAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.MapAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.ListNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.SetNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.MapNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	case dschema.SingleNestedAttribute:
+		a.MarkdownDescription, a.DeprecationMessage = md, deprecation
+		return a
+	default:
+		return att
+	}
+}
@@ -0,0 +1,113 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+)
+
+func TestMarkdownDescription(t *testing.T) {
+	stringSchema := &openapi3.Schema{Type: toTypes(openapi3.TypeString)}
+	enumSchema := &openapi3.Schema{Type: toTypes(openapi3.TypeString), Enum: []any{"NFS", "SMB"}}
+	enumArraySchema := &openapi3.Schema{
+		Type:  toTypes(openapi3.TypeArray),
+		Items: &openapi3.SchemaRef{Value: enumSchema},
+	}
+	hints := &TFStateHints{CapacityFields: map[string]string{"burst_bw_mb": "MB"}}
+
+	tests := []struct {
+		name     string
+		field    string
+		desc     string
+		schema   *openapi3.Schema
+		expected string
+	}{
+		{"plain", "name", "A name.", stringSchema, "A name."},
+		{"enum", "protocol", "Protocol.", enumSchema, "Protocol. Allowed values: `NFS`, `SMB`."},
+		{"enum of array items", "protocols", "Protocols", enumArraySchema, "Protocols. Allowed values: `NFS`, `SMB`."},
+		{"enum without description", "protocol", "", enumSchema, "Allowed values: `NFS`, `SMB`."},
+		{"unit", "latency_ms", "Latency", stringSchema, "Latency. Unit: milliseconds."},
+		{"unit already documented", "eta_sec", "Time until completion, in seconds", stringSchema, "Time until completion, in seconds"},
+		{"bandwidth unit", "wr_bw_mb", "Write Bandwidth", stringSchema, "Write Bandwidth. Unit: MB/s."},
+		{"rate has no unit", "read_iops_per_sec", "", stringSchema, ""},
+		{"capacity field", "burst_bw_mb", "Burst BW", stringSchema, "Burst BW"},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			assert.Equal(t, tt.expected, markdownDescription(tt.field, tt.desc, tt.schema, hints))
+		})
+	}
+}
+
+func TestDocumentAttributes(t *testing.T) {
+	ctx := context.Background()
+	entries := map[string]*SchemaEntry{
+		"legacy_mode": {
+			Prop: &openapi3.Schema{
+				Type:       toTypes(openapi3.TypeString),
+				Enum:       []any{"ON", "OFF"},
+				Deprecated: true,
+			},
+			Optional:    true,
+			Description: "Legacy mode",
+		},
+		"limits": {
+			Prop: &openapi3.Schema{
+				Type: toTypes(openapi3.TypeObject),
+				Properties: map[string]*openapi3.SchemaRef{
+					"latency_ms": {Value: &openapi3.Schema{Type: toTypes(openapi3.TypeInteger), Description: "Latency"}},
+				},
+			},
+			Optional: true,
+		},
+	}
+
+	rattrs := buildResourceAttributesFromMap(ctx, entries, &TFStateHints{})
+	legacy := rattrs["legacy_mode"].(rschema.StringAttribute)
+	assert.Equal(t, "Legacy mode", legacy.Description)
+	assert.Equal(t, "Legacy mode. Allowed values: `ON`, `OFF`.", legacy.MarkdownDescription)
+	assert.Equal(t, openAPIDeprecationMessage, legacy.DeprecationMessage)
+	limits := rattrs["limits"].(rschema.SingleNestedAttribute)
+	latency := limits.Attributes["latency_ms"].(rschema.Int64Attribute)
+	assert.Equal(t, "Latency", latency.Description)
+	assert.Equal(t, "Latency. Unit: milliseconds.", latency.MarkdownDescription)
+	assert.Empty(t, latency.DeprecationMessage)
+
+	entries["legacy_mode"].Computed = true
+	dattrs := buildDatasourceAttributesFromMap(ctx, entries, &TFStateHints{})
+	dlegacy := dattrs["legacy_mode"].(dschema.StringAttribute)
+	assert.Equal(t, "Legacy mode. Allowed values: `ON`, `OFF`.", dlegacy.MarkdownDescription)
+	assert.Equal(t, openAPIDeprecationMessage, dlegacy.DeprecationMessage)
+}
+
+func TestAddSchemaEntries_Descriptions(t *testing.T) {
+	target := map[string]*SchemaEntry{}
+	// Query parameter documents the field ...
+	addSchemaEntries(map[string]*openapi3.SchemaRef{
+		"name": buildTmpSchemaRefFromParam(&openapi3.Parameter{
+			Name:        "name",
+			Description: "Name of the view",
+			Schema:      &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString)}},
+		}),
+	}, nil, nil, target, false, true, false, false, false, false)
+	// ... response property overriding it does not.
+	addSchemaEntries(map[string]*openapi3.SchemaRef{
+		"name": {Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString)}},
+		"tags": {Value: &openapi3.Schema{
+			Type:  toTypes(openapi3.TypeArray),
+			Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString), Title: "Tag"}},
+		}},
+	}, nil, nil, target, false, true, true, false, false, false)
+
+	require.Contains(t, target, "name")
+	assert.Equal(t, "Name of the view", target["name"].Description)
+	assert.True(t, target["name"].Computed)
+	assert.Equal(t, "Tag", target["tags"].Description)
+}
@@ -0,0 +1,30 @@
+// Copyright (c) HashiCorp, Inc.
+
+// Polymorphic (oneOf/anyOf) objects are generated as nested attributes with one optional
+// sub-object per variant (see client.OneOf). Exactly one variant must be configured;
+// is.OneOfType takes care of converting chosen variant into flat API shape (and back).
+
+package schema_generation
+
+import (
+	"maps"
+	"slices"
+
+	"github.com/hashicorp/terraform-plugin-framework-validators/objectvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+)
+
+// oneOfVariantValidators requires exactly one of sibling variants to be set.
+func oneOfVariantValidators[A any](variant string, attrs map[string]A) []validator.Object {
+	var siblings []path.Expression
+	for _, name := range slices.Sorted(maps.Keys(attrs)) {
+		if name != variant {
+			siblings = append(siblings, path.MatchRelative().AtParent().AtName(name))
+		}
+	}
+	if len(siblings) == 0 {
+		return nil
+	}
+	return []validator.Object{objectvalidator.ExactlyOneOf(siblings...)}
+}
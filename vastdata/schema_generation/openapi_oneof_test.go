@@ -0,0 +1,109 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func lifecycleFilterEntries() map[string]*SchemaEntry {
+	stringProp := &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString)}}
+	return map[string]*SchemaEntry{
+		"filter": {
+			Prop: &openapi3.Schema{
+				OneOf: openapi3.SchemaRefs{
+					{Ref: "#/components/schemas/PrefixFilter", Value: &openapi3.Schema{
+						Type:       toTypes(openapi3.TypeObject),
+						Properties: openapi3.Schemas{"type": stringProp, "prefix": stringProp},
+					}},
+					{Ref: "#/components/schemas/TagFilter", Value: &openapi3.Schema{
+						Type:       toTypes(openapi3.TypeObject),
+						Properties: openapi3.Schemas{"type": stringProp, "key": stringProp, "value": stringProp},
+					}},
+				},
+				Discriminator: &openapi3.Discriminator{
+					PropertyName: "type",
+					Mapping: openapi3.StringMap{
+						"prefix": "#/components/schemas/PrefixFilter",
+						"tag":    "#/components/schemas/TagFilter",
+					},
+				},
+			},
+			Optional: true,
+		},
+	}
+}
+
+func TestOneOf_ResourceAttribute(t *testing.T) {
+	ctx := context.Background()
+	attrs := buildResourceAttributesFromMap(ctx, lifecycleFilterEntries(), &TFStateHints{})
+
+	filter := attrs["filter"].(rschema.SingleNestedAttribute)
+	require.IsType(t, is.OneOfType{}, filter.GetType())
+	require.ElementsMatch(t, []string{"prefix", "tag"}, keysOf(filter.Attributes))
+
+	prefix := filter.Attributes["prefix"].(rschema.SingleNestedAttribute)
+	require.True(t, prefix.Optional)
+	require.NotContains(t, prefix.Attributes, "type", "discriminator is implied by variant")
+	require.Len(t, prefix.Validators, 1)
+
+	schema := rschema.Schema{Attributes: attrs}
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+	// Exactly one variant must be configured.
+	filterType := filter.GetType().(is.OneOfType)
+	validate := func(filterRaw map[string]any) bool {
+		filterVal, err := is.BuildAttrValueFromAny(filterType, filterRaw)
+		require.NoError(t, err)
+		tfFilter, err := filterVal.ToTerraformValue(ctx)
+		require.NoError(t, err)
+		config := tfsdk.Config{
+			Schema: schema,
+			Raw: tftypes.NewValue(
+				schema.Type().TerraformType(ctx),
+				map[string]tftypes.Value{"filter": tfFilter},
+			),
+		}
+		resp := &validator.ObjectResponse{}
+		p := path.Root("filter").AtName("prefix")
+		prefix.Validators[0].ValidateObject(ctx, validator.ObjectRequest{
+			Path:           p,
+			PathExpression: p.Expression(),
+			Config:         config,
+			ConfigValue:    filterVal.(is.OneOfValue).Attributes()["prefix"].(types.Object),
+		}, resp)
+		return !resp.Diagnostics.HasError()
+	}
+	require.True(t, validate(map[string]any{"prefix": map[string]any{"prefix": "logs/"}}))
+	require.True(t, validate(map[string]any{"tag": map[string]any{"key": "env"}}))
+	require.False(t, validate(map[string]any{
+		"prefix": map[string]any{"prefix": "logs/"},
+		"tag":    map[string]any{"key": "env"},
+	}))
+}
+
+func TestOneOf_DatasourceAttribute(t *testing.T) {
+	entries := lifecycleFilterEntries()
+	entries["filter"].Optional, entries["filter"].Computed = false, true
+	attrs := buildDatasourceAttributesFromMap(context.Background(), entries, &TFStateHints{})
+	require.IsType(t, is.OneOfType{}, attrs["filter"].GetType())
+}
+
+func keysOf[V any](m map[string]V) []string {
+	out := make([]string, 0, len(m))
+	for k := range m {
+		out = append(out, k)
+	}
+	return out
+}
@@ -0,0 +1,331 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file translates OpenAPI constraints (enum, minimum/maximum, pattern, minLength/maxLength,
+// minItems/maxItems, uniqueItems, minProperties/maxProperties) into Terraform framework validators,
+// so invalid values are reported by `terraform validate` instead of by VMS at apply time.
+
+package schema_generation
+
+import (
+	"context"
+	"fmt"
+	"math"
+	"regexp"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/mapvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/setvalidator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// stringValidatorsFromSchema builds validators from enum, pattern and minLength/maxLength.
+// Patterns that are not supported by Go regexp (RE2) are skipped.
+func stringValidatorsFromSchema(s *openapi3.Schema) []validator.String {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.String
+	if len(s.Enum) > 0 {
+		var values []string
+		for _, v := range s.Enum {
+			if str, ok := v.(string); ok {
+				values = append(values, str)
+			}
+		}
+		if len(values) > 0 {
+			validators = append(validators, stringvalidator.OneOf(values...))
+		}
+	}
+	if s.Pattern != "" {
+		if re, err := regexp.Compile(s.Pattern); err == nil {
+			validators = append(validators, stringvalidator.RegexMatches(re, fmt.Sprintf("value must match pattern %q", s.Pattern)))
+		} else {
+			warnWithContext(context.Background(), fmt.Sprintf("Skipping unsupported pattern %q: %s", s.Pattern, err))
+		}
+	}
+	switch {
+	case s.MaxLength != nil:
+		validators = append(validators, stringvalidator.LengthBetween(int(s.MinLength), int(*s.MaxLength)))
+	case s.MinLength > 0:
+		validators = append(validators, stringvalidator.LengthAtLeast(int(s.MinLength)))
+	}
+	return validators
+}
+
+// int64ValidatorsFromSchema builds validators from enum and minimum/maximum (exclusive bounds included).
+func int64ValidatorsFromSchema(s *openapi3.Schema) []validator.Int64 {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.Int64
+	if len(s.Enum) > 0 {
+		var values []int64
+		for _, v := range s.Enum {
+			switch v := v.(type) {
+			case int:
+				values = append(values, int64(v))
+			case int64:
+				values = append(values, v)
+			case float64:
+				values = append(values, int64(v))
+			}
+		}
+		if len(values) > 0 {
+			validators = append(validators, int64validator.OneOf(values...))
+		}
+	}
+	minVal, maxVal := int64BoundsFromSchema(s)
+	switch {
+	case minVal != nil && maxVal != nil:
+		validators = append(validators, int64validator.Between(*minVal, *maxVal))
+	case minVal != nil:
+		validators = append(validators, int64validator.AtLeast(*minVal))
+	case maxVal != nil:
+		validators = append(validators, int64validator.AtMost(*maxVal))
+	}
+	return validators
+}
+
+// int64BoundsFromSchema returns inclusive integer bounds from minimum/maximum (exclusive bounds included).
+func int64BoundsFromSchema(s *openapi3.Schema) (minVal, maxVal *int64) {
+	if s.Min != nil {
+		v := int64(math.Ceil(*s.Min))
+		if s.ExclusiveMin && float64(v) == *s.Min {
+			v++
+		}
+		minVal = &v
+	}
+	if s.Max != nil {
+		v := int64(math.Floor(*s.Max))
+		if s.ExclusiveMax && float64(v) == *s.Max {
+			v--
+		}
+		maxVal = &v
+	}
+	return minVal, maxVal
+}
+
+// capacityValidatorsFromSchema builds validators of capacity field (see TFStateHints.CapacityFields)
+// from minimum/maximum of its API integer schema. Bounds are checked after conversion into API unit.
+func capacityValidatorsFromSchema(s *openapi3.Schema, t is.CapacityType) []validator.String {
+	if s == nil {
+		return nil
+	}
+	minVal, maxVal := int64BoundsFromSchema(s)
+	if minVal == nil && maxVal == nil {
+		return nil
+	}
+	// Bounds are kept by value: schemas are compared with their precomputed counterparts.
+	v := capacityBoundsValidator{typ: t, min: math.MinInt64, max: math.MaxInt64}
+	if minVal != nil {
+		v.min = *minVal
+	}
+	if maxVal != nil {
+		v.max = *maxVal
+	}
+	return []validator.String{v}
+}
+
+// capacityBoundsValidator checks that capacity converted into API unit is within bounds.
+// Open bounds are math.MinInt64 and math.MaxInt64.
+type capacityBoundsValidator struct {
+	typ      is.CapacityType
+	min, max int64
+}
+
+func (v capacityBoundsValidator) Description(_ context.Context) string {
+	switch {
+	case v.min != math.MinInt64 && v.max != math.MaxInt64:
+		return fmt.Sprintf("capacity must be between %d and %d %s", v.min, v.max, v.typ.Unit)
+	case v.min != math.MinInt64:
+		return fmt.Sprintf("capacity must be at least %d %s", v.min, v.typ.Unit)
+	default:
+		return fmt.Sprintf("capacity must be at most %d %s", v.max, v.typ.Unit)
+	}
+}
+
+func (v capacityBoundsValidator) MarkdownDescription(ctx context.Context) string {
+	return v.Description(ctx)
+}
+
+func (v capacityBoundsValidator) ValidateString(ctx context.Context, req validator.StringRequest, resp *validator.StringResponse) {
+	if req.ConfigValue.IsNull() || req.ConfigValue.IsUnknown() {
+		return
+	}
+	amount, err := is.NewCapacityValue(v.typ, req.ConfigValue.ValueString()).APIAmount()
+	if err != nil {
+		return // reported by CapacityValue.ValidateAttribute
+	}
+	if amount < v.min || amount > v.max {
+		resp.Diagnostics.AddAttributeError(
+			req.Path,
+			"Invalid Capacity Value",
+			fmt.Sprintf("Capacity %q is %d %s, %s.", req.ConfigValue.ValueString(), amount, v.typ.Unit, v.Description(ctx)),
+		)
+	}
+}
+
+// float64ValidatorsFromSchema builds validators from enum and minimum/maximum (exclusive bounds included).
+func float64ValidatorsFromSchema(s *openapi3.Schema) []validator.Float64 {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.Float64
+	if len(s.Enum) > 0 {
+		var values []float64
+		for _, v := range s.Enum {
+			switch v := v.(type) {
+			case float64:
+				values = append(values, v)
+			case int:
+				values = append(values, float64(v))
+			}
+		}
+		if len(values) > 0 {
+			validators = append(validators, float64validator.OneOf(values...))
+		}
+	}
+	var minVal, maxVal *float64
+	if s.Min != nil {
+		v := *s.Min
+		if s.ExclusiveMin {
+			v = math.Nextafter(v, math.Inf(1))
+		}
+		minVal = &v
+	}
+	if s.Max != nil {
+		v := *s.Max
+		if s.ExclusiveMax {
+			v = math.Nextafter(v, math.Inf(-1))
+		}
+		maxVal = &v
+	}
+	switch {
+	case minVal != nil && maxVal != nil:
+		validators = append(validators, float64validator.Between(*minVal, *maxVal))
+	case minVal != nil:
+		validators = append(validators, float64validator.AtLeast(*minVal))
+	case maxVal != nil:
+		validators = append(validators, float64validator.AtMost(*maxVal))
+	}
+	return validators
+}
+
+// listValidatorsFromSchema builds validators of ordered array from minItems/maxItems, uniqueItems
+// and constraints of primitive (or nested array) items.
+func listValidatorsFromSchema(s *openapi3.Schema) []validator.List {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.List
+	switch {
+	case s.MaxItems != nil:
+		validators = append(validators, listvalidator.SizeBetween(int(s.MinItems), int(*s.MaxItems)))
+	case s.MinItems > 0:
+		validators = append(validators, listvalidator.SizeAtLeast(int(s.MinItems)))
+	}
+	if s.UniqueItems {
+		validators = append(validators, listvalidator.UniqueValues())
+	}
+	items := arrayItemsSchema(s)
+	switch getSchemaType(items) {
+	case openapi3.TypeString:
+		if v := stringValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, listvalidator.ValueStringsAre(v...))
+		}
+	case openapi3.TypeInteger:
+		if v := int64ValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, listvalidator.ValueInt64sAre(v...))
+		}
+	case openapi3.TypeNumber:
+		if v := float64ValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, listvalidator.ValueFloat64sAre(v...))
+		}
+	case openapi3.TypeArray:
+		if v := listValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, listvalidator.ValueListsAre(v...))
+		}
+	}
+	return validators
+}
+
+// setValidatorsFromSchema builds validators of unordered array from minItems/maxItems
+// and constraints of primitive (or nested array) items.
+func setValidatorsFromSchema(s *openapi3.Schema) []validator.Set {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.Set
+	switch {
+	case s.MaxItems != nil:
+		validators = append(validators, setvalidator.SizeBetween(int(s.MinItems), int(*s.MaxItems)))
+	case s.MinItems > 0:
+		validators = append(validators, setvalidator.SizeAtLeast(int(s.MinItems)))
+	}
+	items := arrayItemsSchema(s)
+	switch getSchemaType(items) {
+	case openapi3.TypeString:
+		if v := stringValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, setvalidator.ValueStringsAre(v...))
+		}
+	case openapi3.TypeInteger:
+		if v := int64ValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, setvalidator.ValueInt64sAre(v...))
+		}
+	case openapi3.TypeNumber:
+		if v := float64ValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, setvalidator.ValueFloat64sAre(v...))
+		}
+	case openapi3.TypeArray:
+		if v := setValidatorsFromSchema(items); len(v) > 0 {
+			validators = append(validators, setvalidator.ValueSetsAre(v...))
+		}
+	}
+	return validators
+}
+
+// mapValidatorsFromSchema builds validators of map from minProperties/maxProperties
+// and constraints of primitive values (additionalProperties).
+func mapValidatorsFromSchema(s *openapi3.Schema) []validator.Map {
+	if s == nil {
+		return nil
+	}
+	var validators []validator.Map
+	switch {
+	case s.MaxProps != nil:
+		validators = append(validators, mapvalidator.SizeBetween(int(s.MinProps), int(*s.MaxProps)))
+	case s.MinProps > 0:
+		validators = append(validators, mapvalidator.SizeAtLeast(int(s.MinProps)))
+	}
+	if s.AdditionalProperties.Schema == nil {
+		return validators
+	}
+	values := resolveComposedSchema(resolveAllRefs(s.AdditionalProperties.Schema))
+	switch getSchemaType(values) {
+	case openapi3.TypeString:
+		if v := stringValidatorsFromSchema(values); len(v) > 0 {
+			validators = append(validators, mapvalidator.ValueStringsAre(v...))
+		}
+	case openapi3.TypeInteger:
+		if v := int64ValidatorsFromSchema(values); len(v) > 0 {
+			validators = append(validators, mapvalidator.ValueInt64sAre(v...))
+		}
+	case openapi3.TypeNumber:
+		if v := float64ValidatorsFromSchema(values); len(v) > 0 {
+			validators = append(validators, mapvalidator.ValueFloat64sAre(v...))
+		}
+	}
+	return validators
+}
+
+func arrayItemsSchema(s *openapi3.Schema) *openapi3.Schema {
+	if s == nil || s.Items == nil {
+		return nil
+	}
+	return resolveComposedSchema(resolveAllRefs(s.Items))
+}
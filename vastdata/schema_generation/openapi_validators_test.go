@@ -0,0 +1,204 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"testing"
+
+	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func validString(validators []validator.String, value string) bool {
+	resp := &validator.StringResponse{}
+	for _, v := range validators {
+		v.ValidateString(context.Background(), validator.StringRequest{Path: path.Root("f"), ConfigValue: types.StringValue(value)}, resp)
+	}
+	return !resp.Diagnostics.HasError()
+}
+
+func validInt64(validators []validator.Int64, value int64) bool {
+	resp := &validator.Int64Response{}
+	for _, v := range validators {
+		v.ValidateInt64(context.Background(), validator.Int64Request{Path: path.Root("f"), ConfigValue: types.Int64Value(value)}, resp)
+	}
+	return !resp.Diagnostics.HasError()
+}
+
+func validFloat64(validators []validator.Float64, value float64) bool {
+	resp := &validator.Float64Response{}
+	for _, v := range validators {
+		v.ValidateFloat64(context.Background(), validator.Float64Request{Path: path.Root("f"), ConfigValue: types.Float64Value(value)}, resp)
+	}
+	return !resp.Diagnostics.HasError()
+}
+
+func validList(validators []validator.List, value types.List) bool {
+	resp := &validator.ListResponse{}
+	for _, v := range validators {
+		v.ValidateList(context.Background(), validator.ListRequest{Path: path.Root("f"), ConfigValue: value}, resp)
+	}
+	return !resp.Diagnostics.HasError()
+}
+
+func validSet(validators []validator.Set, value types.Set) bool {
+	resp := &validator.SetResponse{}
+	for _, v := range validators {
+		v.ValidateSet(context.Background(), validator.SetRequest{Path: path.Root("f"), ConfigValue: value}, resp)
+	}
+	return !resp.Diagnostics.HasError()
+}
+
+func stringList(values ...string) types.List {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.ListValueMust(types.StringType, elems)
+}
+
+func stringSet(values ...string) types.Set {
+	elems := make([]attr.Value, 0, len(values))
+	for _, v := range values {
+		elems = append(elems, types.StringValue(v))
+	}
+	return types.SetValueMust(types.StringType, elems)
+}
+
+func TestOpenAPIValidators_String(t *testing.T) {
+	maxLen := uint64(9)
+	validators := stringValidatorsFromSchema(&openapi3.Schema{
+		Type:      toTypes(openapi3.TypeString),
+		Enum:      []any{"NFS", "SMB", "S3-bucket", nil},
+		Pattern:   "^[A-Z]",
+		MinLength: 2,
+		MaxLength: &maxLen,
+	})
+	require.Len(t, validators, 3)
+	require.True(t, validString(validators, "NFS"))
+	require.True(t, validString(validators, "S3-bucket"))
+	require.False(t, validString(validators, "nfs"))
+	require.False(t, validString(validators, "S3-buckets"))
+
+	// Patterns not supported by RE2 are skipped.
+	require.Empty(t, stringValidatorsFromSchema(&openapi3.Schema{Pattern: "^(?!tmp)"}))
+
+	validators = stringValidatorsFromSchema(&openapi3.Schema{MinLength: 3})
+	require.True(t, validString(validators, "abc"))
+	require.False(t, validString(validators, "ab"))
+}
+
+func TestOpenAPIValidators_Int64(t *testing.T) {
+	minVal, maxVal := 0.0, 100.0
+	validators := int64ValidatorsFromSchema(&openapi3.Schema{Min: &minVal, Max: &maxVal, ExclusiveMin: true})
+	require.Len(t, validators, 1)
+	require.False(t, validInt64(validators, 0))
+	require.True(t, validInt64(validators, 1))
+	require.True(t, validInt64(validators, 100))
+	require.False(t, validInt64(validators, 101))
+
+	validators = int64ValidatorsFromSchema(&openapi3.Schema{Min: &minVal})
+	require.True(t, validInt64(validators, 1<<62))
+	require.False(t, validInt64(validators, -1))
+
+	validators = int64ValidatorsFromSchema(&openapi3.Schema{Enum: []any{float64(1), float64(2)}})
+	require.True(t, validInt64(validators, 2))
+	require.False(t, validInt64(validators, 3))
+}
+
+func TestOpenAPIValidators_Capacity(t *testing.T) {
+	minVal, maxVal := 0.0, 1000.0
+	validators := capacityValidatorsFromSchema(&openapi3.Schema{Min: &minVal, Max: &maxVal}, is.NewCapacityType("MB"))
+	require.Len(t, validators, 1)
+	require.True(t, validString(validators, "1GB"))
+	require.True(t, validString(validators, "1000000000"))
+	// Bounds are in API unit (MB), not in bytes.
+	require.False(t, validString(validators, "1001MB"))
+	require.False(t, validString(validators, "1TB"))
+	require.False(t, validString(validators, "-1MB"))
+	// Malformed values are reported by the capacity type itself.
+	require.True(t, validString(validators, "10 terabytes"))
+
+	require.Empty(t, capacityValidatorsFromSchema(&openapi3.Schema{}, is.NewCapacityType("B")))
+}
+
+func TestOpenAPIValidators_Float64(t *testing.T) {
+	maxVal := 1.0
+	validators := float64ValidatorsFromSchema(&openapi3.Schema{Max: &maxVal, ExclusiveMax: true})
+	require.True(t, validFloat64(validators, 0.99))
+	require.False(t, validFloat64(validators, 1))
+}
+
+func TestOpenAPIValidators_Arrays(t *testing.T) {
+	maxItems := uint64(3)
+	schema := &openapi3.Schema{
+		Type:        toTypes(openapi3.TypeArray),
+		MinItems:    1,
+		MaxItems:    &maxItems,
+		UniqueItems: true,
+		Items: &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type: toTypes(openapi3.TypeString),
+			Enum: []any{"NFS", "SMB", "S3"},
+		}},
+	}
+
+	listValidators := listValidatorsFromSchema(schema)
+	require.True(t, validList(listValidators, stringList("NFS", "S3")))
+	require.False(t, validList(listValidators, stringList()))
+	require.False(t, validList(listValidators, stringList("NFS", "NFS")))
+	require.False(t, validList(listValidators, stringList("NFS", "FTP")))
+	require.False(t, validList(listValidators, stringList("NFS", "SMB", "S3", "NFS")))
+
+	setValidators := setValidatorsFromSchema(schema)
+	require.True(t, validSet(setValidators, stringSet("SMB")))
+	require.False(t, validSet(setValidators, stringSet("FTP")))
+
+	// IP range pairs: inner arrays have exactly two items.
+	pairs := &openapi3.Schema{
+		Type: toTypes(openapi3.TypeArray),
+		Items: &openapi3.SchemaRef{Value: &openapi3.Schema{
+			Type:     toTypes(openapi3.TypeArray),
+			MinItems: 2,
+			MaxItems: &[]uint64{2}[0],
+			Items:    &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString)}},
+		}},
+	}
+	pairType := types.ListType{ElemType: types.StringType}
+	pairValidators := listValidatorsFromSchema(pairs)
+	require.True(t, validList(pairValidators, types.ListValueMust(pairType, []attr.Value{stringList("10.0.0.1", "10.0.0.9")})))
+	require.False(t, validList(pairValidators, types.ListValueMust(pairType, []attr.Value{stringList("10.0.0.1")})))
+}
+
+func TestOpenAPIValidators_NestedAttributes(t *testing.T) {
+	maxVal := 10.0
+	entries := map[string]*SchemaEntry{
+		"limits": {
+			Prop: &openapi3.Schema{
+				Type: toTypes(openapi3.TypeObject),
+				Properties: map[string]*openapi3.SchemaRef{
+					"max_iops": {Value: &openapi3.Schema{Type: toTypes(openapi3.TypeInteger), Max: &maxVal}},
+					"mode":     {Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString), Enum: []any{"static", "dynamic"}}},
+					"protocols": {Value: &openapi3.Schema{
+						Type:  toTypes(openapi3.TypeArray),
+						Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: toTypes(openapi3.TypeString), Enum: []any{"NFS", "SMB"}}},
+					}},
+				},
+			},
+			Optional: true,
+		},
+	}
+
+	attrs := buildResourceAttributesFromMap(context.Background(), entries, &TFStateHints{})
+	nested := attrs["limits"].(rschema.SingleNestedAttribute).Attributes
+	require.False(t, validInt64(nested["max_iops"].(rschema.Int64Attribute).Validators, 11))
+	require.False(t, validString(nested["mode"].(rschema.StringAttribute).Validators, "burst"))
+	require.False(t, validSet(nested["protocols"].(rschema.SetAttribute).Validators, stringSet("S3")))
+	require.True(t, validSet(nested["protocols"].(rschema.SetAttribute).Validators, stringSet("NFS")))
+}
@@ -9,23 +9,36 @@ package schema_generation
 import (
 	"context"
 	"fmt"
+	"maps"
 	"net/http"
+	"slices"
 	"strings"
 
 	"github.com/getkin/kin-openapi/openapi3"
-	"github.com/hashicorp/terraform-plugin-framework-validators/float64validator"
-	"github.com/hashicorp/terraform-plugin-framework-validators/int64validator"
+	"github.com/hashicorp/terraform-plugin-framework-validators/listvalidator"
 	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
 
 func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schema, error) {
 
 	if hints.TFStateHintsForCustom != nil {
 		// Build schema for custom resource
-		return getResourceSchemaForCustom(hints)
+		schema, err := getResourceSchemaForCustom(hints)
+		if err != nil {
+			return nil, err
+		}
+		if err = addReferenceAttributes(schema.Attributes, hints); err != nil {
+			return nil, err
+		}
+		addIgnoreRemoteChangesAttribute(schema.Attributes, hints)
+		addTimeoutsAttribute(schema.Attributes, hints)
+		return schema, nil
 	}
 
 	if hints.SchemaRef == nil {
@@ -157,26 +170,25 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 	if modelSchemaRef.Value != nil {
 		// Add fields from the POST request schema (typically Optional)
 		for name, ref := range modelSchemaRef.Value.Properties {
+			if existing, ok := allProps[name]; ok && isFreeFormObject(existing.Prop) && ref.Value != nil && !isAmbiguousObject(ref.Value) {
+				// POST schema declares field as free-form object, while response model defines its shape.
+				// Use model shape for request as well.
+				infoWithContext(ctx, fmt.Sprintf("POST schema for '%s' is ambiguous. Using schema from response model", name))
+				delete(allProps, name)
+				addSchemaEntries(map[string]*openapi3.SchemaRef{name: ref}, requiredFields, hints, allProps, false, true, true, false, false, false)
+				continue
+			}
 			if existing, ok := allProps[name]; ok {
-				if isAmbiguousObject(ref.Value) {
-					existing.Computed = false
-					existingSchemaJson := schemaToJSONString(existing.Prop)
-					warnWithContext(
-						ctx,
-						fmt.Sprintf(
-							"POST schema for '%s' is ambiguous. Using schema %q from 'GET' in 'read-only' mode ",
-							name,
-							existingSchemaJson,
-						),
-					)
-					continue
+				if existing.Description == "" {
+					// POST schema often omits descriptions documented in response model.
+					existing.Description = schemaDescription(ref.Value)
 				}
-
 				if diffReason, ok := compareSchemaValues(existing.Prop, ref.Value); ok {
 					if !existing.Required {
 						// Field is present in both POST and GET with identical schema.
 						// Normally mark as computed, unless explicitly overridden by hints.NotComputedSchemaFields.
-						if hints != nil && contains(hints.NotComputedSchemaFields, name) {
+						// Write-only fields are never computed.
+						if existing.WriteOnly || (hints != nil && contains(hints.NotComputedSchemaFields, name)) {
 							existing.Computed = false
 						} else {
 							existing.Computed = true
@@ -200,6 +212,10 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 		}
 	}
 
+	if resourceMethod == http.MethodPost {
+		markImmutableEntries(ctx, resourcePath, allProps)
+	}
+
 	// Will be optional only fields (Query parameters).
 	params, err := client.QueryParametersGET(resourcePath)
 	if err != nil {
@@ -221,7 +237,10 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 		if strings.Contains(name, "__") {
 			continue
 		}
-		if _, exists := allProps[name]; exists {
+		if existing, exists := allProps[name]; exists {
+			if existing.Description == "" {
+				existing.Description = p.Description
+			}
 			continue
 		}
 		if !contains(excludeSearchParams, name) && !contains(hints.ReadOnlyFields, name) {
@@ -255,6 +274,11 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 	}
 
 	attrs := buildResourceAttributesFromMap(ctx, allProps, hints)
+	props := make(map[string]*openapi3.Schema, len(allProps))
+	for name, entry := range allProps {
+		props[name] = entry.Prop
+	}
+	applyOpenAPIDefaults(ctx, attrs, props, hints)
 	if hints.AdditionalSchemaAttributes != nil {
 		for k, v := range hints.AdditionalSchemaAttributes {
 			att, ok := v.(rschema.Attribute)
@@ -264,6 +288,12 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 			attrs[k] = att
 		}
 	}
+	addWriteOnlyVersionAttributes(attrs, hints)
+	if err = addReferenceAttributes(attrs, hints); err != nil {
+		return nil, err
+	}
+	addIgnoreRemoteChangesAttribute(attrs, hints)
+	addTimeoutsAttribute(attrs, hints)
 
 	return &rschema.Schema{
 		Description:         description,
@@ -272,6 +302,25 @@ func GetResourceSchema(ctx context.Context, hints *TFStateHints) (*rschema.Schem
 	}, nil
 }
 
+// markImmutableEntries sets Immutable flag of computed entries from PATCH request schema of the resource
+// ("<resourcePath>/{id}"). Entries are left untouched if the resource has no PATCH request schema.
+func markImmutableEntries(ctx context.Context, resourcePath string, entries map[string]*SchemaEntry) {
+	patchPath := strings.Trim(resourcePath, "/") + "/{id}"
+	patchSchemaRef, err := client.GetSchema_PATCH_RequestBody(patchPath)
+	if err != nil || IsEmptySchema(patchSchemaRef) {
+		infoWithContext(ctx, fmt.Sprintf("No PATCH schema for %q: stable computed fields are not detected", patchPath))
+		return
+	}
+	for name, entry := range entries {
+		if !entry.Computed {
+			continue
+		}
+		_, updatable := patchSchemaRef.Value.Properties[name]
+		immutable := !updatable
+		entry.Immutable = &immutable
+	}
+}
+
 func getResourceSchemaForCustom(hints *TFStateHints) (*rschema.Schema, error) {
 	customHints := hints.TFStateHintsForCustom
 	if customHints.SchemaAttributes == nil || len(customHints.SchemaAttributes) == 0 {
@@ -311,7 +360,7 @@ func buildResourceAttributesFromMap(ctx context.Context, entries map[string]*Sch
 		if attr == nil {
 			continue
 		}
-		result[name] = attr
+		result[name] = documentResourceAttribute(name, attr, schema, hints)
 	}
 	return result
 }
@@ -333,6 +382,7 @@ func buildResourceAttribute(
 	switch (*schema.Type)[0] {
 	case openapi3.TypeString:
 		att := rschema.StringAttribute{
+			CustomType:          customStringType(name, hints),
 			Required:            entry.Required,
 			Optional:            entry.Optional,
 			Computed:            entry.Computed,
@@ -347,22 +397,28 @@ func buildResourceAttribute(
 				att.Validators = append(att.Validators, validators...)
 			}
 		}
-		// OpenAPI enum
-		if len(schema.Enum) > 0 {
-			var enumValues []string
-			for _, val := range schema.Enum {
-				if str, ok := val.(string); ok {
-					enumValues = append(enumValues, str)
-				}
-			}
-			if len(enumValues) > 0 {
-				att.Validators = append(att.Validators, stringvalidator.OneOf(enumValues...))
-			}
-		}
+		att.Validators = append(att.Validators, stringValidatorsFromSchema(schema)...)
 
-		return injectModifiers(att, name, hints)
+		return injectModifiers(att, name, entry, hints)
 
 	case openapi3.TypeInteger:
+		if unit, ok := capacityUnit(name, hints); ok {
+			capacityDesc := capacityDescription(desc)
+			capacityType := is.NewCapacityType(unit)
+			att := rschema.StringAttribute{
+				CustomType:          capacityType,
+				Required:            entry.Required,
+				Optional:            entry.Optional,
+				Computed:            entry.Computed,
+				WriteOnly:           entry.WriteOnly,
+				Sensitive:           entry.Sensitive,
+				Description:         capacityDesc,
+				MarkdownDescription: capacityDesc,
+				Validators:          capacityValidatorsFromSchema(schema, capacityType),
+			}
+			return injectModifiers(att, name, entry, hints)
+		}
+
 		att := rschema.Int64Attribute{
 			Required:            entry.Required,
 			Optional:            entry.Optional,
@@ -379,33 +435,9 @@ func buildResourceAttribute(
 			}
 		}
 
-		// OpenAPI enum
-		if len(schema.Enum) > 0 {
-			var enumValues []int64
-			for _, val := range schema.Enum {
-				switch v := val.(type) {
-				case int:
-					enumValues = append(enumValues, int64(v))
-				case int64:
-					enumValues = append(enumValues, v)
-				case float64:
-					enumValues = append(enumValues, int64(v))
-				}
-			}
-			if len(enumValues) > 0 {
-				att.Validators = append(att.Validators, int64validator.OneOf(enumValues...))
-			}
-		}
-		// Min/max validation
-		if schema.Min != nil && schema.Max != nil {
-			att.Validators = append(att.Validators, int64validator.Between(int64(*schema.Min), int64(*schema.Max)))
-		} else if schema.Min != nil {
-			att.Validators = append(att.Validators, int64validator.Between(int64(*schema.Min), int64(^uint64(0)>>1))) // MaxInt64
-		} else if schema.Max != nil {
-			att.Validators = append(att.Validators, int64validator.Between(int64(-1<<63), int64(*schema.Max))) // MinInt64
-		}
+		att.Validators = append(att.Validators, int64ValidatorsFromSchema(schema)...)
 
-		return injectModifiers(att, name, hints)
+		return injectModifiers(att, name, entry, hints)
 
 	case openapi3.TypeNumber:
 		att := rschema.Float64Attribute{
@@ -424,23 +456,9 @@ func buildResourceAttribute(
 			}
 		}
 
-		// OpenAPI enum
-		if len(schema.Enum) > 0 {
-			var floatValues []float64
-			for _, val := range schema.Enum {
-				switch v := val.(type) {
-				case float64:
-					floatValues = append(floatValues, v)
-				case int: // fallback handling
-					floatValues = append(floatValues, float64(v))
-				}
-			}
-			if len(floatValues) > 0 {
-				att.Validators = append(att.Validators, float64validator.OneOf(floatValues...))
-			}
-		}
+		att.Validators = append(att.Validators, float64ValidatorsFromSchema(schema)...)
 
-		return injectModifiers(att, name, hints)
+		return injectModifiers(att, name, entry, hints)
 
 	case openapi3.TypeBoolean:
 		att := rschema.BoolAttribute{
@@ -453,7 +471,7 @@ func buildResourceAttribute(
 			MarkdownDescription: desc,
 		}
 
-		return injectModifiers(att, name, hints)
+		return injectModifiers(att, name, entry, hints)
 
 	case openapi3.TypeArray:
 		itemSchema := resolveComposedSchema(resolveAllRefs(schema.Items))
@@ -461,36 +479,37 @@ func buildResourceAttribute(
 			panic("invalid item schema for array")
 		}
 
-		if (*itemSchema.Type)[0] == openapi3.TypeObject && len(itemSchema.Properties) == 0 {
-			warnWithContext(ctx, fmt.Sprintf("Skipping. Nested object for field %q: no properties defined", name))
-			return nil
-		}
-
 		isOrdered := entry.Ordered
 
-		switch (*itemSchema.Type)[0] {
-		case openapi3.TypeObject:
+		// Free-form object items are JSON strings (see default branch).
+		switch itemType := (*itemSchema.Type)[0]; {
+		case itemType == openapi3.TypeObject && !isAmbiguousObject(itemSchema):
 			nested := make(map[string]*SchemaEntry)
 			addSchemaEntries(itemSchema.Properties, itemSchema.Required, hints, nested, entry.Required, entry.Optional, entry.Computed, entry.WriteOnly, entry.Sensitive, entry.Ordered)
 			attributes := buildResourceAttributesFromMap(ctx, nested, hints)
+			customType := resourceObjectType(itemSchema, attributes)
 
 			if isOrdered {
 				return rschema.ListNestedAttribute{
 					NestedObject: rschema.NestedAttributeObject{
 						Attributes: attributes,
+						CustomType: customType,
 					},
 					Required:            entry.Required,
 					Optional:            entry.Optional,
 					Computed:            entry.Computed,
+					WriteOnly:           entry.WriteOnly,
 					Sensitive:           entry.Sensitive,
 					Description:         desc,
 					MarkdownDescription: desc,
+					Validators:          listValidatorsFromSchema(schema),
 				}
 			}
 
 			return rschema.SetNestedAttribute{
 				NestedObject: rschema.NestedAttributeObject{
 					Attributes: attributes,
+					CustomType: customType,
 				},
 				Required:            entry.Required,
 				Optional:            entry.Optional,
@@ -498,23 +517,29 @@ func buildResourceAttribute(
 				Sensitive:           entry.Sensitive,
 				Description:         desc,
 				MarkdownDescription: desc,
+				Validators:          setValidatorsFromSchema(schema),
 			}
 
-		case openapi3.TypeArray:
+		case itemType == openapi3.TypeArray:
 			inner := resolveComposedSchema(resolveAllRefs(itemSchema.Items))
 			innerType := buildAttrTypeFromSchema(inner)
 
-			if isOrdered {
+			// Custom list types compare values semantically, so they are lists even if order does not matter.
+			customType := customListType(name, hints)
+			if isOrdered || customType != nil {
 				att := rschema.ListAttribute{
+					CustomType:          customType,
 					ElementType:         types.ListType{ElemType: innerType},
 					Required:            entry.Required,
 					Optional:            entry.Optional,
 					Computed:            entry.Computed,
+					WriteOnly:           entry.WriteOnly,
 					Sensitive:           entry.Sensitive,
 					Description:         desc,
 					MarkdownDescription: desc,
+					Validators:          listValidatorsFromSchema(schema),
 				}
-				return injectModifiers(att, name, hints)
+				return injectModifiers(att, name, entry, hints)
 			}
 
 			att := rschema.SetAttribute{
@@ -525,8 +550,9 @@ func buildResourceAttribute(
 				Sensitive:           entry.Sensitive,
 				Description:         desc,
 				MarkdownDescription: desc,
+				Validators:          setValidatorsFromSchema(schema),
 			}
-			return injectModifiers(att, name, hints)
+			return injectModifiers(att, name, entry, hints)
 
 		default:
 			elemType := buildAttrTypeFromSchema(itemSchema)
@@ -537,11 +563,13 @@ func buildResourceAttribute(
 					Required:            entry.Required,
 					Optional:            entry.Optional,
 					Computed:            entry.Computed,
+					WriteOnly:           entry.WriteOnly,
 					Sensitive:           entry.Sensitive,
 					Description:         desc,
 					MarkdownDescription: desc,
+					Validators:          listValidatorsFromSchema(schema),
 				}
-				return injectModifiers(att, name, hints)
+				return injectModifiers(att, name, entry, hints)
 			}
 
 			att := rschema.SetAttribute{
@@ -552,33 +580,48 @@ func buildResourceAttribute(
 				Sensitive:           entry.Sensitive,
 				Description:         desc,
 				MarkdownDescription: desc,
+				Validators:          setValidatorsFromSchema(schema),
 			}
-			return injectModifiers(att, name, hints)
+			return injectModifiers(att, name, entry, hints)
 		}
 
 	case openapi3.TypeObject:
-		if schema.AdditionalProperties.Schema != nil {
-			valSchema := resolveComposedSchema(resolveAllRefs(schema.AdditionalProperties.Schema))
-			if valSchema.Type != nil && (*valSchema.Type)[0] == openapi3.TypeObject && len(valSchema.Properties) == 0 {
-				warnWithContext(ctx, fmt.Sprintf("Skipping. Map value schema for %q is empty object", name))
-				return nil
+		if isFreeFormObject(schema) {
+			// Free-form object (including maps declared via additionalProperties) is passed as JSON string.
+			att := rschema.StringAttribute{
+				CustomType:          is.JSONStringType{},
+				Required:            entry.Required,
+				Optional:            entry.Optional,
+				Computed:            entry.Computed,
+				WriteOnly:           entry.WriteOnly,
+				Sensitive:           entry.Sensitive,
+				Description:         desc,
+				MarkdownDescription: desc,
 			}
+			return injectModifiers(att, name, entry, hints)
+		}
 
+		if schema.AdditionalProperties.Schema != nil {
+			valSchema := resolveComposedSchema(resolveAllRefs(schema.AdditionalProperties.Schema))
 			valType := buildAttrTypeFromSchema(valSchema)
 
 			if valSchema.Type != nil && (*valSchema.Type)[0] == openapi3.TypeObject && len(valSchema.Properties) > 0 {
 				nested := make(map[string]*SchemaEntry)
 				addSchemaEntries(valSchema.Properties, valSchema.Required, hints, nested, entry.Required, entry.Optional, entry.Computed, entry.WriteOnly, entry.Sensitive, entry.Ordered)
+				attributes := buildResourceAttributesFromMap(ctx, nested, hints)
 				return rschema.MapNestedAttribute{
 					NestedObject: rschema.NestedAttributeObject{
-						Attributes: buildResourceAttributesFromMap(ctx, nested, hints),
+						Attributes: attributes,
+						CustomType: resourceObjectType(valSchema, attributes),
 					},
 					Required:            entry.Required,
 					Optional:            entry.Optional,
 					Computed:            entry.Computed,
+					WriteOnly:           entry.WriteOnly,
 					Sensitive:           entry.Sensitive,
 					Description:         desc,
 					MarkdownDescription: desc,
+					Validators:          mapValidatorsFromSchema(schema),
 				}
 			}
 
@@ -587,21 +630,26 @@ func buildResourceAttribute(
 				Required:            entry.Required,
 				Optional:            entry.Optional,
 				Computed:            entry.Computed,
+				WriteOnly:           entry.WriteOnly,
 				Sensitive:           entry.Sensitive,
 				Description:         desc,
 				MarkdownDescription: desc,
+				Validators:          mapValidatorsFromSchema(schema),
 			}
-			return injectModifiers(att, name, hints)
+			return injectModifiers(att, name, entry, hints)
 		}
 
 		if len(schema.Properties) > 0 {
 			nested := make(map[string]*SchemaEntry)
 			addSchemaEntries(schema.Properties, schema.Required, hints, nested, entry.Required, entry.Optional, entry.Computed, entry.WriteOnly, entry.Sensitive, entry.Ordered)
+			attributes := buildResourceAttributesFromMap(ctx, nested, hints)
 			return rschema.SingleNestedAttribute{
-				Attributes:          buildResourceAttributesFromMap(ctx, nested, hints),
+				Attributes:          attributes,
+				CustomType:          resourceObjectType(schema, attributes),
 				Required:            entry.Required,
 				Optional:            entry.Optional,
 				Computed:            entry.Computed,
+				WriteOnly:           entry.WriteOnly,
 				Sensitive:           entry.Sensitive,
 				Description:         desc,
 				MarkdownDescription: desc,
@@ -615,3 +663,138 @@ func buildResourceAttribute(
 		panic(fmt.Sprintf("unsupported schema type %q for attribute %q", (*schema.Type)[0], name))
 	}
 }
+
+// addWriteOnlyVersionAttributes adds companion "<field>_version" attribute for every top-level write-only field.
+// Write-only values are never stored in state, so Terraform cannot detect their changes.
+// Changing the version value triggers update which re-sends the write-only value.
+func addWriteOnlyVersionAttributes(attrs map[string]rschema.Attribute, hints *TFStateHints) {
+	for _, name := range hints.WriteOnlyFields {
+		att, ok := attrs[name]
+		if !ok || !att.IsWriteOnly() {
+			continue
+		}
+		versionField := is.WriteOnlyVersionField(name)
+		if _, exists := attrs[versionField]; exists {
+			continue
+		}
+		desc := fmt.Sprintf(
+			"Version of the write-only %q attribute. %q is not stored in state; change this value to send a new %q on update.",
+			name, name, name,
+		)
+		attrs[versionField] = rschema.Int64Attribute{
+			Optional:            true,
+			Description:         desc,
+			MarkdownDescription: desc,
+		}
+	}
+}
+
+// addReferenceAttributes adds name based alternatives of id fields declared in ReferenceFields hint.
+// The id field becomes optional and computed (it is resolved from the name when the name is used),
+// the name field conflicts with the id field or, if the id field was required, exactly one of them is required.
+func addReferenceAttributes(attrs map[string]rschema.Attribute, hints *TFStateHints) error {
+	for _, name := range slices.Sorted(maps.Keys(hints.ReferenceFields)) {
+		ref := hints.ReferenceFields[name]
+		idAttr, ok := attrs[ref.Field]
+		if !ok {
+			return fmt.Errorf("reference field %q: id field %q is not in schema", name, ref.Field)
+		}
+		idAttrInt, ok := idAttr.(rschema.Int64Attribute)
+		if !ok {
+			return fmt.Errorf("reference field %q: id field %q must be Int64 (got %T)", name, ref.Field, idAttr)
+		}
+
+		var nameValidator validator.String
+		if idAttrInt.Required {
+			nameValidator = stringvalidator.ExactlyOneOf(path.MatchRoot(ref.Field))
+		} else {
+			nameValidator = stringvalidator.ConflictsWith(path.MatchRoot(ref.Field))
+		}
+		idAttrInt.Required, idAttrInt.Optional, idAttrInt.Computed = false, true, true
+		attrs[ref.Field] = idAttrInt
+
+		searchField := ref.ReferenceSearchField()
+		note := fmt.Sprintf("Alternative to %q: the %s is resolved to the ID before create, read and update.", ref.Field, searchField)
+		nameAttr := rschema.StringAttribute{
+			Description: fmt.Sprintf("%s%s of the %s.", strings.ToUpper(searchField[:1]), searchField[1:], strings.ReplaceAll(ref.Target, "_", " ")),
+		}
+		if existing, exists := attrs[name]; exists {
+			if nameAttr, ok = existing.(rschema.StringAttribute); !ok {
+				return fmt.Errorf("reference field %q must be String (got %T)", name, existing)
+			}
+		}
+		if nameAttr.Description == "" {
+			nameAttr.Description = note
+		} else {
+			nameAttr.Description = strings.TrimRight(nameAttr.Description, ". ") + ". " + note
+		}
+		nameAttr.MarkdownDescription = nameAttr.Description
+		nameAttr.Required, nameAttr.Optional, nameAttr.Computed = false, true, true
+		nameAttr.Validators = append(nameAttr.Validators, nameValidator)
+		attrs[name] = nameAttr
+	}
+	return nil
+}
+
+// addIgnoreRemoteChangesAttribute adds "ignore_remote_changes" attribute which lists computed attributes
+// whose changes made outside of Terraform are ignored on refresh (see VolatileFields hint).
+// The attribute is not added if the resource has no computed attributes.
+func addIgnoreRemoteChangesAttribute(attrs map[string]rschema.Attribute, hints *TFStateHints) {
+	if _, exists := attrs[is.IgnoreRemoteChangesField]; exists {
+		return
+	}
+	var computed []string
+	for name, att := range attrs {
+		if att.IsComputed() && !att.IsWriteOnly() && !contains(hints.WriteOnlyFields, name) {
+			computed = append(computed, name)
+		}
+	}
+	if len(computed) == 0 {
+		return
+	}
+	slices.Sort(computed)
+
+	desc := "Computed attributes whose changes made outside of Terraform are ignored on refresh: " +
+		"once set, their values are kept from state until the resource is updated."
+	if len(hints.VolatileFields) > 0 {
+		desc += fmt.Sprintf(" Always ignored: %s.", strings.Join(hints.VolatileFields, ", "))
+	}
+	attrs[is.IgnoreRemoteChangesField] = rschema.ListAttribute{
+		ElementType:         types.StringType,
+		Optional:            true,
+		Description:         desc,
+		MarkdownDescription: desc,
+		Validators: []validator.List{
+			listvalidator.ValueStringsAre(stringvalidator.OneOf(computed...)),
+		},
+	}
+}
+
+// addTimeoutsAttribute adds "timeouts" attribute to resources which retry deletion blocked by
+// dependent objects (see DeleteRetryOnConflict hint). Its "delete" value bounds the retries.
+func addTimeoutsAttribute(attrs map[string]rschema.Attribute, hints *TFStateHints) {
+	if hints.DeleteRetryOnConflict == nil {
+		return
+	}
+	if _, exists := attrs[is.TimeoutsField]; exists {
+		return
+	}
+	deleteDesc := fmt.Sprintf(
+		"Time to keep retrying deletion while the object is still in use by other objects, "+
+			"as a Go duration string (e.g. \"30s\", \"10m\"). Defaults to %s.", hints.DeleteRetryOnConflict.DefaultTimeout(),
+	)
+	desc := "Operation timeouts."
+	attrs[is.TimeoutsField] = rschema.SingleNestedAttribute{
+		Optional:            true,
+		Description:         desc,
+		MarkdownDescription: desc,
+		Attributes: map[string]rschema.Attribute{
+			"delete": rschema.StringAttribute{
+				Optional:            true,
+				Description:         deleteDesc,
+				MarkdownDescription: deleteDesc,
+				Validators:          []validator.String{DurationValidator()},
+			},
+		},
+	}
+}
@@ -12,12 +12,18 @@ import (
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/mapplanmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/setplanmodifier"
 	"testing"
+	"time"
 
 	"github.com/getkin/kin-openapi/openapi3"
+	"github.com/hashicorp/terraform-plugin-framework/attr"
+	"github.com/hashicorp/terraform-plugin-framework/path"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
+	"github.com/hashicorp/terraform-plugin-framework/types"
 	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
 
 func containsUseStateForUnknown(pm any) bool {
@@ -140,7 +146,7 @@ func Test_buildResourceAttribute_MapOfInt(t *testing.T) {
 	require.IsType(t, rschema.MapAttribute{}, attrs["metrics"])
 }
 
-func Test_buildResourceAttribute_EmptyObjectsAreSkipped(t *testing.T) {
+func Test_buildResourceAttribute_FreeFormObjectsAreJSONStrings(t *testing.T) {
 	entries := map[string]*SchemaEntry{
 		"empty_object": {
 			Prop: &openapi3.Schema{
@@ -166,12 +172,13 @@ func Test_buildResourceAttribute_EmptyObjectsAreSkipped(t *testing.T) {
 
 	attrs := buildResourceAttributesFromMap(context.TODO(), entries, &TFStateHints{})
 
-	// Assert that both fields are skipped (not present in final schema)
-	_, exists1 := attrs["empty_object"]
-	_, exists2 := attrs["map_with_empty_object_values"]
-
-	require.False(t, exists1, "empty_object should be skipped")
-	require.False(t, exists2, "map_with_empty_object_values should be skipped")
+	for _, name := range []string{"empty_object", "map_with_empty_object_values"} {
+		require.Contains(t, attrs, name)
+		att, ok := attrs[name].(rschema.StringAttribute)
+		require.True(t, ok, "%s should be string attribute", name)
+		require.Equal(t, is.JSONStringType{}, att.CustomType)
+		require.True(t, att.Computed)
+	}
 }
 
 func Test_buildResourceAttribute_OrderedListOfStrings(t *testing.T) {
@@ -314,7 +321,7 @@ func Test_injectModifiers_ComputedOnlyGetsUseState(t *testing.T) {
 		Computed: true,
 	}
 
-	modified := injectModifiers(att, "name", &TFStateHints{})
+	modified := injectModifiers(att, "name", nil, &TFStateHints{})
 	mod, ok := modified.(rschema.StringAttribute)
 	require.True(t, ok)
 	require.True(t, containsUseStateForUnknown(mod.PlanModifiers), "Expected UseStateForUnknown for computed-only attribute")
@@ -326,12 +333,49 @@ func Test_injectModifiers_OptionalDoesNotGetUseState(t *testing.T) {
 		Computed: true,
 	}
 
-	modified := injectModifiers(att, "name", &TFStateHints{})
+	modified := injectModifiers(att, "name", nil, &TFStateHints{})
 	mod, ok := modified.(rschema.StringAttribute)
 	require.True(t, ok)
 	require.False(t, containsUseStateForUnknown(mod.PlanModifiers), "Should not apply UseStateForUnknown to optional+computed field")
 }
 
+func Test_injectModifiers_StableComputed(t *testing.T) {
+	immutable, mutable := true, false
+	hints := &TFStateHints{
+		StableComputedFields: []string{"forced"},
+		VolatileFields:       []string{"used_capacity"},
+		ReferenceFields: map[string]is.ReferenceField{
+			"tenant_name": {Target: "tenant", Field: "tenant_id"},
+		},
+	}
+	readOnly := rschema.Int64Attribute{Computed: true}
+	optionalComputed := rschema.Int64Attribute{Optional: true, Computed: true}
+
+	tests := []struct {
+		name   string
+		attr   rschema.Int64Attribute
+		entry  *SchemaEntry
+		stable bool
+	}{
+		{name: "guid", attr: readOnly, entry: &SchemaEntry{Immutable: &immutable}, stable: true},
+		{name: "created", attr: readOnly, entry: &SchemaEntry{}, stable: true},
+		{name: "state", attr: readOnly, entry: &SchemaEntry{Immutable: &mutable}, stable: false},
+		{name: "used_capacity", attr: readOnly, entry: &SchemaEntry{Immutable: &immutable}, stable: false},
+		{name: "owner", attr: optionalComputed, entry: &SchemaEntry{Immutable: &immutable}, stable: true},
+		{name: "quota", attr: optionalComputed, entry: &SchemaEntry{Immutable: &mutable}, stable: false},
+		{name: "quota", attr: optionalComputed, entry: &SchemaEntry{}, stable: false},
+		{name: "tenant_id", attr: optionalComputed, entry: &SchemaEntry{Immutable: &immutable}, stable: false},
+		{name: "tenant_name", attr: readOnly, entry: &SchemaEntry{Immutable: &immutable}, stable: false},
+		{name: "forced", attr: optionalComputed, entry: &SchemaEntry{Immutable: &mutable}, stable: true},
+		{name: "forced", attr: rschema.Int64Attribute{Optional: true}, entry: nil, stable: false},
+		{name: "secret", attr: rschema.Int64Attribute{Computed: true, Sensitive: true}, entry: nil, stable: false},
+	}
+	for _, tt := range tests {
+		modified := injectModifiers(tt.attr, tt.name, tt.entry, hints).(rschema.Int64Attribute)
+		require.Equal(t, tt.stable, containsUseStateForUnknown(modified.PlanModifiers), "%s %v", tt.name, tt.entry)
+	}
+}
+
 func Test_injectModifiers_FromHints(t *testing.T) {
 	att := rschema.StringAttribute{
 		Optional: true,
@@ -343,8 +387,118 @@ func Test_injectModifiers_FromHints(t *testing.T) {
 		},
 	}
 
-	modified := injectModifiers(att, "force_field", hints)
+	modified := injectModifiers(att, "force_field", nil, hints)
 	mod, ok := modified.(rschema.StringAttribute)
 	require.True(t, ok)
 	require.Len(t, mod.PlanModifiers, 1)
 }
+
+func TestAddWriteOnlyVersionAttributes(t *testing.T) {
+	attrs := map[string]rschema.Attribute{
+		"password": rschema.StringAttribute{Optional: true, WriteOnly: true},
+		"token":    rschema.StringAttribute{Optional: true},
+	}
+	addWriteOnlyVersionAttributes(attrs, &TFStateHints{WriteOnlyFields: []string{"password", "token", "missing"}})
+
+	require.Contains(t, attrs, "password_version")
+	require.True(t, attrs["password_version"].IsOptional())
+	require.False(t, attrs["password_version"].IsWriteOnly())
+	// Not write-only in the schema or absent: no companion.
+	require.NotContains(t, attrs, "token_version")
+	require.NotContains(t, attrs, "missing_version")
+}
+
+func TestAddReferenceAttributes(t *testing.T) {
+	ctx := context.Background()
+	attrs := map[string]rschema.Attribute{
+		"tenant_id":   rschema.Int64Attribute{Optional: true},
+		"tenant_name": rschema.StringAttribute{Computed: true, Description: "Tenant Name"},
+		"user_id":     rschema.Int64Attribute{Required: true},
+	}
+	err := addReferenceAttributes(attrs, &TFStateHints{ReferenceFields: map[string]is.ReferenceField{
+		"tenant_name": {Target: "tenant", Field: "tenant_id"},
+		"username":    {Target: "user", Field: "user_id"},
+	}})
+	require.NoError(t, err)
+
+	for _, idField := range []string{"tenant_id", "user_id"} {
+		require.True(t, attrs[idField].IsOptional(), idField)
+		require.True(t, attrs[idField].IsComputed(), idField)
+		require.False(t, attrs[idField].IsRequired(), idField)
+	}
+
+	tenantName := attrs["tenant_name"].(rschema.StringAttribute)
+	require.True(t, tenantName.Optional)
+	require.True(t, tenantName.Computed)
+	require.Equal(t, `Tenant Name. Alternative to "tenant_id": the name is resolved to the ID before create, read and update.`, tenantName.Description)
+	require.Len(t, tenantName.Validators, 1)
+	require.Contains(t, tenantName.Validators[0].Description(ctx), "these are not set")
+
+	username := attrs["username"].(rschema.StringAttribute)
+	require.True(t, username.Optional)
+	require.Equal(t, `Name of the user. Alternative to "user_id": the name is resolved to the ID before create, read and update.`, username.Description)
+	require.Len(t, username.Validators, 1)
+	require.Contains(t, username.Validators[0].Description(ctx), "Ensure that one and only one attribute")
+
+	schema := rschema.Schema{Attributes: attrs}
+	require.False(t, schema.ValidateImplementation(ctx).HasError())
+
+	err = addReferenceAttributes(attrs, &TFStateHints{ReferenceFields: map[string]is.ReferenceField{
+		"policy_name": {Target: "view_policy", Field: "policy_id"},
+	}})
+	require.ErrorContains(t, err, `id field "policy_id" is not in schema`)
+}
+
+func TestAddTimeoutsAttribute(t *testing.T) {
+	attrs := map[string]rschema.Attribute{"name": rschema.StringAttribute{Required: true}}
+	addTimeoutsAttribute(attrs, &TFStateHints{})
+	require.NotContains(t, attrs, is.TimeoutsField, "deletion is not retried")
+
+	addTimeoutsAttribute(attrs, &TFStateHints{DeleteRetryOnConflict: &is.DeleteRetryOnConflict{Timeout: 10 * time.Minute}})
+	att, ok := attrs[is.TimeoutsField].(rschema.SingleNestedAttribute)
+	require.True(t, ok)
+	require.True(t, att.Optional)
+	require.False(t, att.Computed)
+	deleteAttr, ok := att.Attributes["delete"].(rschema.StringAttribute)
+	require.True(t, ok)
+	require.Contains(t, deleteAttr.Description, "Defaults to 10m0s.")
+	require.Len(t, deleteAttr.Validators, 1)
+}
+
+func TestAddIgnoreRemoteChangesAttribute(t *testing.T) {
+	ctx := context.Background()
+	attrs := map[string]rschema.Attribute{
+		"name":             rschema.StringAttribute{Required: true},
+		"logical_capacity": rschema.Int64Attribute{Computed: true},
+		"sync_time":        rschema.StringAttribute{Computed: true},
+	}
+	addIgnoreRemoteChangesAttribute(attrs, &TFStateHints{VolatileFields: []string{"logical_capacity"}})
+
+	att, ok := attrs[is.IgnoreRemoteChangesField].(rschema.ListAttribute)
+	require.True(t, ok)
+	require.True(t, att.Optional)
+	require.False(t, att.Computed)
+	require.Contains(t, att.Description, "Always ignored: logical_capacity.")
+	require.Len(t, att.Validators, 1)
+
+	validate := func(values ...string) bool {
+		elems := make([]attr.Value, 0, len(values))
+		for _, v := range values {
+			elems = append(elems, types.StringValue(v))
+		}
+		req := validator.ListRequest{
+			Path:        path.Root(is.IgnoreRemoteChangesField),
+			ConfigValue: types.ListValueMust(types.StringType, elems),
+		}
+		resp := &validator.ListResponse{}
+		att.Validators[0].ValidateList(ctx, req, resp)
+		return !resp.Diagnostics.HasError()
+	}
+	require.True(t, validate("sync_time", "logical_capacity"))
+	require.False(t, validate("name"))
+
+	// No computed attributes: nothing to ignore.
+	attrs = map[string]rschema.Attribute{"name": rschema.StringAttribute{Required: true}}
+	addIgnoreRemoteChangesAttribute(attrs, &TFStateHints{})
+	require.NotContains(t, attrs, is.IgnoreRemoteChangesField)
+}
@@ -0,0 +1,86 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file implements memoization of generated schemas.
+// Schemas depend only on the embedded OpenAPI document and hints of the component, both fixed at build time,
+// while the framework requests them repeatedly: for the resource, for the data source, for the ephemeral
+// resource and again on each import. So schemas are generated once per spec version, component and context.
+
+package schema_generation
+
+import (
+	"context"
+	"sync"
+	"sync/atomic"
+
+	dschema "github.com/hashicorp/terraform-plugin-framework/datasource/schema"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+type schemaCacheKey struct {
+	specVersion string
+	component   string
+	kind        is.SchemaContext
+}
+
+// schemaCacheEntry holds schema (or error) generated once for concurrent callers.
+type schemaCacheEntry struct {
+	once   sync.Once
+	schema any
+	err    error
+}
+
+var (
+	schemaCache         sync.Map // schemaCacheKey -> *schemaCacheEntry
+	schemaCacheDisabled atomic.Bool
+)
+
+// SetSchemaCache enables or disables memoization of generated schemas (enabled by default).
+// Cached schemas are dropped in both cases.
+func SetSchemaCache(enabled bool) {
+	schemaCacheDisabled.Store(!enabled)
+	schemaCache.Clear()
+}
+
+// GetCachedResourceSchema returns GetResourceSchema of the component, generating it only once.
+// Callers get their own copy of the schema (eg to set Version), attributes are shared and must not be modified.
+func GetCachedResourceSchema(ctx context.Context, component string, hints *TFStateHints) (*rschema.Schema, error) {
+	schema, err := cachedSchema(component, is.SchemaForResource, hints, func() (any, error) {
+		return GetResourceSchema(ctx, hints)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cp := *schema.(*rschema.Schema)
+	return &cp, nil
+}
+
+// GetCachedDatasourceSchema returns GetDatasourceSchema of the component, generating it only once.
+// Callers get their own copy of the schema, attributes are shared and must not be modified.
+func GetCachedDatasourceSchema(ctx context.Context, component string, hints *TFStateHints) (*dschema.Schema, error) {
+	schema, err := cachedSchema(component, is.SchemaForDataSource, hints, func() (any, error) {
+		return GetDatasourceSchema(ctx, hints)
+	})
+	if err != nil {
+		return nil, err
+	}
+	cp := *schema.(*dschema.Schema)
+	return &cp, nil
+}
+
+// cachedSchema returns result of generate memoized per spec version, component and kind.
+// Errors are memoized as well: generation is deterministic, so retry would fail the same way.
+// Schemas of custom components are not generated from the OpenAPI document and are not memoized.
+func cachedSchema(component string, kind is.SchemaContext, hints *TFStateHints, generate func() (any, error)) (any, error) {
+	if schemaCacheDisabled.Load() || hints.TFStateHintsForCustom != nil {
+		return generate()
+	}
+	key := schemaCacheKey{specVersion: client.OpenAPISpecVersion, component: component, kind: kind}
+	value, _ := schemaCache.LoadOrStore(key, &schemaCacheEntry{})
+	entry := value.(*schemaCacheEntry)
+	entry.once.Do(func() {
+		entry.schema, entry.err = generate()
+	})
+	return entry.schema, entry.err
+}
@@ -0,0 +1,117 @@
+// Copyright (c) HashiCorp, Inc.
+
+package schema_generation
+
+import (
+	"context"
+	"errors"
+	"net/http"
+	"sync"
+	"testing"
+
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+func TestSchemaCache_GeneratesOnce(t *testing.T) {
+	SetSchemaCache(true)
+	defer SetSchemaCache(true)
+
+	var calls int
+	hints := &is.TFStateHints{}
+	generate := func() (any, error) {
+		calls++
+		return calls, nil
+	}
+	first, err := cachedSchema("user", is.SchemaForResource, hints, generate)
+	require.NoError(t, err)
+	second, err := cachedSchema("user", is.SchemaForResource, hints, generate)
+	require.NoError(t, err)
+	assert.Equal(t, 1, first)
+	assert.Equal(t, 1, second)
+
+	// Data source of the same component is a separate entry.
+	datasource, err := cachedSchema("user", is.SchemaForDataSource, hints, generate)
+	require.NoError(t, err)
+	assert.Equal(t, 2, datasource)
+
+	// Errors are memoized too.
+	failing := func() (any, error) {
+		calls++
+		return nil, errors.New("boom")
+	}
+	_, err = cachedSchema("broken", is.SchemaForResource, hints, failing)
+	assert.EqualError(t, err, "boom")
+	_, err = cachedSchema("broken", is.SchemaForResource, hints, failing)
+	assert.EqualError(t, err, "boom")
+	assert.Equal(t, 3, calls)
+
+	// Schemas of custom components are not memoized.
+	custom := &is.TFStateHints{TFStateHintsForCustom: &is.TFStateHintsForCustom{}}
+	_, _ = cachedSchema("custom", is.SchemaForResource, custom, generate)
+	_, _ = cachedSchema("custom", is.SchemaForResource, custom, generate)
+	assert.Equal(t, 5, calls)
+
+	// Disabled cache generates schema on every call.
+	SetSchemaCache(false)
+	_, _ = cachedSchema("user", is.SchemaForResource, hints, generate)
+	_, _ = cachedSchema("user", is.SchemaForResource, hints, generate)
+	assert.Equal(t, 7, calls)
+}
+
+func TestSchemaCache_Concurrent(t *testing.T) {
+	SetSchemaCache(true)
+	defer SetSchemaCache(true)
+
+	var (
+		mu    sync.Mutex
+		calls int
+		wg    sync.WaitGroup
+		hints = &is.TFStateHints{}
+	)
+	generate := func() (any, error) {
+		mu.Lock()
+		defer mu.Unlock()
+		calls++
+		return calls, nil
+	}
+	for range 16 {
+		wg.Add(1)
+		go func() {
+			defer wg.Done()
+			schema, err := cachedSchema("view", is.SchemaForResource, hints, generate)
+			assert.NoError(t, err)
+			assert.Equal(t, 1, schema)
+		}()
+	}
+	wg.Wait()
+	assert.Equal(t, 1, calls)
+}
+
+func TestGetCachedResourceSchema(t *testing.T) {
+	SetSchemaCache(true)
+	defer SetSchemaCache(true)
+
+	ctx := context.Background()
+	hints := &is.TFStateHints{
+		SchemaRef: is.NewSchemaReference(http.MethodPost, "users", http.MethodGet, "users"),
+	}
+	expected, err := GetResourceSchema(ctx, hints)
+	require.NoError(t, err)
+
+	cached, err := GetCachedResourceSchema(ctx, "user", hints)
+	require.NoError(t, err)
+	assert.Equal(t, len(expected.Attributes), len(cached.Attributes))
+	assert.Contains(t, cached.Attributes, "name")
+
+	// Each caller gets its own copy of the schema.
+	cached.Version = 42
+	again, err := GetCachedResourceSchema(ctx, "user", hints)
+	require.NoError(t, err)
+	assert.Zero(t, again.Version)
+
+	datasource, err := GetCachedDatasourceSchema(ctx, "user", hints)
+	require.NoError(t, err)
+	assert.Contains(t, datasource.Attributes, "name")
+}
@@ -29,13 +29,16 @@ var resolveComposedSchema = client.ResolveComposedSchema
 var resolveAllRefs = client.ResolveAllRefs
 
 type SchemaEntry struct {
-	Prop        *openapi3.Schema
-	Required    bool
-	Optional    bool
-	Computed    bool
-	WriteOnly   bool
-	Sensitive   bool
-	Ordered     bool
+	Prop      *openapi3.Schema
+	Required  bool
+	Optional  bool
+	Computed  bool
+	WriteOnly bool
+	Sensitive bool
+	Ordered   bool
+	// Immutable reports whether the field cannot be changed by update (absent from PATCH request schema).
+	// Nil when PATCH schema of the resource is not known.
+	Immutable   *bool
 	Description string
 	Children    map[string]*SchemaEntry
 }
@@ -64,24 +67,27 @@ func addSchemaEntries(
 		if schema == nil || isExcluded(name, hints) {
 			continue
 		}
-		if isAmbiguousObject(schema) {
-			// Skip ambiguous objects with no properties
-			warnWithContext(context.Background(), fmt.Sprintf("Skipping ambiguous object schema for field '%s' with no properties", name))
-			continue
-		}
 		// Requiredness is determined strictly by this object's required array
 		fieldRequired := contains(requiredFields, name)
 		fieldOptional := optional
 		fieldComputed := computed
 		fieldWriteOnly := writeOnly
 		fieldSensitive := sensitive
-		fieldOrdered := ordered
+		// VMS returns arrays of primitives in arbitrary order, so they are sets unless marked
+		// with PreserveOrderFields themselves (order of enclosing array does not apply to them).
+		fieldOrdered := ordered && !isPrimitiveArray(schema)
 
 		fieldRequired, fieldOptional, fieldComputed, fieldWriteOnly, fieldSensitive, fieldOrdered = flagsFromHintsForResource(name, hints, fieldRequired, fieldOptional, fieldComputed, fieldSensitive, fieldOrdered, fieldWriteOnly)
+		if fieldWriteOnly && !writeOnly && computed {
+			// WriteOnlyFields hint matched a read-only field (e.g. attribute of computed object with the same name).
+			// Computed values cannot be write-only.
+			fieldWriteOnly, fieldComputed, fieldOptional = false, computed, optional
+		}
 
-		desc := schema.Description
-		if desc == "" {
-			desc = schema.Title
+		desc := schemaDescription(schema)
+		if prev, ok := target[name]; ok && desc == "" {
+			// Keep description of the entry being replaced (e.g. query parameter overridden by response property).
+			desc = prev.Description
 		}
 
 		// Ensure at least one flag is set; default to Optional when none provided
@@ -122,6 +128,26 @@ func addSchemaEntries(
 	}
 }
 
+// schemaDescription returns description of the property falling back to its title
+// and, for arrays, to description or title of items.
+func schemaDescription(schema *openapi3.Schema) string {
+	if schema == nil {
+		return ""
+	}
+	if schema.Description != "" {
+		return schema.Description
+	}
+	if schema.Title != "" {
+		return schema.Title
+	}
+	if getSchemaType(schema) == openapi3.TypeArray && schema.Items != nil {
+		if items := resolveComposedSchema(resolveAllRefs(schema.Items)); items != nil && items != schema {
+			return schemaDescription(items)
+		}
+	}
+	return ""
+}
+
 func buildTmpSchemaRefFromParam(p *openapi3.Parameter) *openapi3.SchemaRef {
 	if p == nil || p.Schema == nil || p.Schema.Value == nil {
 		return nil
@@ -190,8 +216,47 @@ func isObject(prop *openapi3.Schema) bool {
 	return prop.Type != nil && len(*prop.Type) > 0 && (*prop.Type)[0] == openapi3.TypeObject
 }
 
+// isPrimitiveArray reports whether prop is array of primitives (strings, numbers, booleans).
+func isPrimitiveArray(prop *openapi3.Schema) bool {
+	if getSchemaType(prop) != openapi3.TypeArray || prop.Items == nil {
+		return false
+	}
+	return isPrimitive(resolveComposedSchema(resolveAllRefs(prop.Items)))
+}
+
 func isAmbiguousObject(prop *openapi3.Schema) bool {
-	return isObject(prop) && len(prop.Properties) == 0
+	return isObject(prop) && len(prop.Properties) == 0 && len(prop.OneOf) == 0 && len(prop.AnyOf) == 0
+}
+
+// isFreeFormObject reports whether prop is object of arbitrary shape: object without properties
+// whose additionalProperties (if declared) are free-form as well.
+// Such objects are exposed as JSON strings (see is.JSONStringType).
+func isFreeFormObject(prop *openapi3.Schema) bool {
+	if !isAmbiguousObject(prop) {
+		return false
+	}
+	if prop.AdditionalProperties.Schema == nil {
+		return true
+	}
+	return isFreeFormObject(resolveComposedSchema(resolveAllRefs(prop.AdditionalProperties.Schema)))
+}
+
+// capacityUnit returns API unit of capacity field declared in TFStateHints.CapacityFields.
+func capacityUnit(name string, hints *TFStateHints) (string, bool) {
+	if hints == nil {
+		return "", false
+	}
+	unit, ok := hints.CapacityFields[name]
+	return unit, ok
+}
+
+// capacityDescription extends description of capacity field (see TFStateHints.CapacityFields) with accepted formats.
+func capacityDescription(desc string) string {
+	note := `Accepts a number or a size with SI/IEC unit (e.g. "500GB", "10TiB", "1.5PB").`
+	if desc == "" {
+		return note
+	}
+	return strings.TrimRight(desc, ". ") + ". " + note
 }
 
 func isExcluded(name string, hints *TFStateHints) bool {
@@ -280,10 +345,21 @@ func buildAttrTypeFromSchema(schema *openapi3.Schema) attr.Type {
 			ElemType: buildAttrTypeFromSchema(resolveComposedSchema(resolveAllRefs(schema.Items))),
 		}
 	case openapi3.TypeObject:
+		if isFreeFormObject(schema) {
+			return is.JSONStringType{}
+		}
+		if len(schema.Properties) == 0 && schema.AdditionalProperties.Schema != nil {
+			return types.MapType{
+				ElemType: buildAttrTypeFromSchema(resolveComposedSchema(resolveAllRefs(schema.AdditionalProperties.Schema))),
+			}
+		}
 		attrTypes := make(map[string]attr.Type)
 		for name, prop := range schema.Properties {
 			attrTypes[name] = buildAttrTypeFromSchema(resolveComposedSchema(resolveAllRefs(prop)))
 		}
+		if customType := objectCustomType(schema, attrTypes); customType != nil {
+			return customType
+		}
 		return types.ObjectType{AttrTypes: attrTypes}
 	default:
 		panic(fmt.Sprintf("unsupported schema type: %q", (*schema.Type)[0]))
@@ -363,9 +439,10 @@ func getSchemaType(s *openapi3.Schema) string {
 	return (*s.Type)[0]
 }
 
-// injectModifiers applies plan modifiers from hints and automatically adds UseStateForUnknown()
-// to computed-only attributes (i.e., not required, optional, or sensitive).
-func injectModifiers(attr schema.Attribute, name string, hints *TFStateHints) schema.Attribute {
+// injectModifiers applies plan modifiers from hints and adds UseStateForUnknown()
+// to stable computed attributes (see isStableComputed).
+func injectModifiers(attr schema.Attribute, name string, entry *SchemaEntry, hints *TFStateHints) schema.Attribute {
+	stable := isStableComputed(attr, name, entry, hints)
 	switch a := attr.(type) {
 
 	case schema.StringAttribute:
@@ -376,7 +453,7 @@ func injectModifiers(attr schema.Attribute, name string, hints *TFStateHints) sc
 				}
 			}
 		}
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, stringplanmodifier.UseStateForUnknown())
 		}
 		return a
@@ -389,7 +466,7 @@ func injectModifiers(attr schema.Attribute, name string, hints *TFStateHints) sc
 				}
 			}
 		}
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, int64planmodifier.UseStateForUnknown())
 		}
 		return a
@@ -402,31 +479,31 @@ func injectModifiers(attr schema.Attribute, name string, hints *TFStateHints) sc
 				}
 			}
 		}
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, float64planmodifier.UseStateForUnknown())
 		}
 		return a
 
 	case schema.BoolAttribute:
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, boolplanmodifier.UseStateForUnknown())
 		}
 		return a
 
 	case schema.ListAttribute:
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, listplanmodifier.UseStateForUnknown())
 		}
 		return a
 
 	case schema.SetAttribute:
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, setplanmodifier.UseStateForUnknown())
 		}
 		return a
 
 	case schema.MapAttribute:
-		if a.Computed && !a.Required && !a.Optional && !a.Sensitive {
+		if stable {
 			a.PlanModifiers = append(a.PlanModifiers, mapplanmodifier.UseStateForUnknown())
 		}
 		return a
@@ -436,3 +513,35 @@ func injectModifiers(attr schema.Attribute, name string, hints *TFStateHints) sc
 		return attr
 	}
 }
+
+// isStableComputed reports whether computed attribute keeps its value across updates,
+// so plans can reuse the prior state value (UseStateForUnknown) instead of "(known after apply)":
+//   - fields from VolatileFields hint and reference fields (id resolved from name and the name itself) are never stable;
+//   - fields from StableComputedFields hint are always stable;
+//   - read-only fields are stable unless they can be set by update (PATCH);
+//   - optional computed fields are stable only if they are known to be absent from PATCH request schema.
+//
+// Sensitive attributes are never stable.
+func isStableComputed(attr schema.Attribute, name string, entry *SchemaEntry, hints *TFStateHints) bool {
+	if !attr.IsComputed() || attr.IsRequired() || attr.IsSensitive() {
+		return false
+	}
+	if hints != nil {
+		if contains(hints.VolatileFields, name) {
+			return false
+		}
+		for refName, ref := range hints.ReferenceFields {
+			if name == refName || name == ref.Field {
+				return false
+			}
+		}
+		if contains(hints.StableComputedFields, name) {
+			return true
+		}
+	}
+	immutable := entry != nil && entry.Immutable != nil && *entry.Immutable
+	if !attr.IsOptional() {
+		return entry == nil || entry.Immutable == nil || immutable
+	}
+	return immutable
+}
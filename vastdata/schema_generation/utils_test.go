@@ -23,6 +23,30 @@ func TestAddSchemaEntries_PrimitiveTypes(t *testing.T) {
 	require.True(t, target["bar"].Optional)
 }
 
+func TestAddSchemaEntries_PrimitiveArraysAreUnordered(t *testing.T) {
+	stringType := (*openapi3.Types)(&[]string{openapi3.TypeString})
+	arrayType := (*openapi3.Types)(&[]string{openapi3.TypeArray})
+	props := map[string]*openapi3.SchemaRef{
+		"hosts":  {Value: &openapi3.Schema{Type: arrayType, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: stringType}}}},
+		"frames": {Value: &openapi3.Schema{Type: arrayType, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: stringType}}}},
+		"nested": {Value: &openapi3.Schema{Type: arrayType, Items: &openapi3.SchemaRef{Value: &openapi3.Schema{Type: arrayType}}}},
+	}
+	hints := &TFStateHints{PreserveOrderFields: []string{"frames"}}
+
+	// Order of enclosing array is not inherited by arrays of primitives.
+	target := map[string]*SchemaEntry{}
+	addSchemaEntries(props, nil, hints, target, false, true, false, false, false, true)
+	require.False(t, target["hosts"].Ordered)
+	require.True(t, target["frames"].Ordered)
+	require.True(t, target["nested"].Ordered)
+
+	target = map[string]*SchemaEntry{}
+	addSchemaEntries(props, nil, hints, target, false, true, false, false, false, false)
+	require.False(t, target["hosts"].Ordered)
+	require.True(t, target["frames"].Ordered)
+	require.False(t, target["nested"].Ordered)
+}
+
 func TestGetSchemaType(t *testing.T) {
 	t.Run("nil schema", func(t *testing.T) {
 		require.Equal(t, "", getSchemaType(nil))
@@ -0,0 +1,145 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"fmt"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/datasource"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/client"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+	"github.com/vast-data/terraform-provider-vastdata/vastdata/schema_generation"
+)
+
+// schemasOf returns schemas of all resources and datasources keyed by component name.
+func schemasOf(t *testing.T) (map[string]resource.SchemaResponse, map[string]datasource.SchemaResponse) {
+	ctx := context.Background()
+	resources := make(map[string]resource.SchemaResponse)
+	for _, f := range GetResourceFactories() {
+		r := f().(*Resource)
+		var resp resource.SchemaResponse
+		r.Schema(ctx, resource.SchemaRequest{}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "resource %s: %v", r.managerName, resp.Diagnostics)
+		resources[r.managerName] = resp
+	}
+	datasources := make(map[string]datasource.SchemaResponse)
+	for _, f := range GetDatasourceFactories() {
+		d := f().(*Datasource)
+		var resp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+		require.False(t, resp.Diagnostics.HasError(), "datasource %s: %v", d.managerName, resp.Diagnostics)
+		datasources[d.managerName] = resp
+	}
+	return resources, datasources
+}
+
+// schemaDump renders schema for comparison. Unlike reflect.DeepEqual, functions of plan modifiers
+// (eg RequiresReplaceIf) are compared by code address.
+func schemaDump(schema any) string {
+	return fmt.Sprintf("%#v", schema)
+}
+
+func TestSchemaIR_UpToDate(t *testing.T) {
+	for _, f := range GetResourceFactories() {
+		r := f().(*Resource)
+		hints := r.EmptyManager().TfState().Hints
+		if hints.SchemaRef == nil {
+			continue
+		}
+		for _, ref := range []*is.OpenAPIEndpointRef{hints.SchemaRef.Create, hints.SchemaRef.Read} {
+			if ref == nil {
+				continue
+			}
+			assert.True(t, client.HasPrecomputedSchema(ref.Path),
+				"resource %s: path %q is not precomputed, run \"go generate ./vastdata/client/\"", r.managerName, ref.Path)
+		}
+	}
+}
+
+func TestSchemaIR_MatchesRuntimeSchemas(t *testing.T) {
+	// Both passes generate schemas from scratch.
+	schema_generation.SetSchemaCache(false)
+	client.SetResolvedSchemaCache(false)
+	defer schema_generation.SetSchemaCache(true)
+	defer client.SetResolvedSchemaCache(true)
+
+	precomputedResources, precomputedDatasources := schemasOf(t)
+
+	client.SetPrecomputedSchemas(false)
+	defer client.SetPrecomputedSchemas(true)
+	runtimeResources, runtimeDatasources := schemasOf(t)
+
+	require.Equal(t, len(runtimeResources), len(precomputedResources))
+	for name, expected := range runtimeResources {
+		assert.Equal(t, schemaDump(expected.Schema), schemaDump(precomputedResources[name].Schema), "resource %s", name)
+	}
+	require.Equal(t, len(runtimeDatasources), len(precomputedDatasources))
+	for name, expected := range runtimeDatasources {
+		assert.Equal(t, schemaDump(expected.Schema), schemaDump(precomputedDatasources[name].Schema), "datasource %s", name)
+	}
+}
+
+// getProviderSchema requests schemas the way the framework serves GetProviderSchema and import:
+// resource and data source schema of every component, then resource schema once more.
+func getProviderSchema(b *testing.B, resources []*Resource, datasources []*Datasource) {
+	ctx := context.Background()
+	for _, r := range resources {
+		for range 2 {
+			var resp resource.SchemaResponse
+			r.Schema(ctx, resource.SchemaRequest{}, &resp)
+			if resp.Diagnostics.HasError() {
+				b.Fatalf("resource %s: %v", r.managerName, resp.Diagnostics)
+			}
+		}
+	}
+	for _, d := range datasources {
+		var resp datasource.SchemaResponse
+		d.Schema(ctx, datasource.SchemaRequest{}, &resp)
+		if resp.Diagnostics.HasError() {
+			b.Fatalf("datasource %s: %v", d.managerName, resp.Diagnostics)
+		}
+	}
+}
+
+// BenchmarkGetProviderSchema measures full provider schema pass with and without memoization
+// (schema cache and client cache of resolved schemas). "cached_cold" starts each pass with empty caches,
+// "cached_warm" reuses results of previous passes.
+func BenchmarkGetProviderSchema(b *testing.B) {
+	var (
+		resources   []*Resource
+		datasources []*Datasource
+	)
+	for _, f := range GetResourceFactories() {
+		resources = append(resources, f().(*Resource))
+	}
+	for _, f := range GetDatasourceFactories() {
+		datasources = append(datasources, f().(*Datasource))
+	}
+	setCaches := func(enabled bool) {
+		schema_generation.SetSchemaCache(enabled)
+		client.SetResolvedSchemaCache(enabled)
+	}
+	defer setCaches(true)
+
+	for _, bc := range []struct {
+		name   string
+		cached bool
+		reset  bool
+	}{{"uncached", false, true}, {"cached_cold", true, true}, {"cached_warm", true, false}} {
+		b.Run(bc.name, func(b *testing.B) {
+			setCaches(bc.cached)
+			b.ResetTimer()
+			for i := 0; i < b.N; i++ {
+				if bc.reset {
+					setCaches(bc.cached)
+				}
+				getProviderSchema(b, resources, datasources)
+			}
+		})
+	}
+}
@@ -29,6 +29,10 @@ func (m *Snapshot) NewResourceManager(raw map[string]attr.Value, schema any) Res
 				"path":            ValidatorPathStartsEndsWithSlash,
 				"expiration_time": ValidatorRFC3339Format,
 			},
+			CustomTypeMapping: map[string]string{
+				"path": CustomTypePath,
+			},
+			VolatileFields: []string{"aggr_phys_estimation", "unique_phys_estimation", "eta_sec"},
 		},
 	)}
 }
@@ -0,0 +1,29 @@
+// Copyright (c) HashiCorp, Inc.
+
+// This file holds the state upgrade registry. Generated schemas follow the embedded
+// OpenAPI spec, so attributes can be renamed, retyped or removed between provider releases.
+// Each such change must be accompanied by a declarative upgrade here so that existing
+// state keeps decoding.
+//
+// The schema version of a component equals the number of its registered upgrades:
+// a component without upgrades stays at version 0. To add a change for "view"
+// currently at version 1, append an upgrade with FromVersion 1:
+//
+//	"view": {
+//	    {FromVersion: 0, Ops: []is.StateUpgradeOp{is.DropField{Field: "legacy_flag"}}},
+//	    {FromVersion: 1, Ops: []is.StateUpgradeOp{is.RenameField{From: "policy", To: "policy_id"}}},
+//	},
+
+package provider
+
+import (
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// stateUpgraders maps component name (snake case, e.g. "view_policy") to its ordered state upgrades.
+var stateUpgraders = map[string][]is.StateUpgrade{}
+
+// stateSchemaVersion returns the current schema version for the given component.
+func stateSchemaVersion(managerName string) (int64, error) {
+	return is.StateSchemaVersion(stateUpgraders[managerName])
+}
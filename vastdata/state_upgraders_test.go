@@ -0,0 +1,293 @@
+// Copyright (c) HashiCorp, Inc.
+
+package provider
+
+import (
+	"context"
+	"testing"
+
+	"github.com/hashicorp/terraform-plugin-framework/path"
+	"github.com/hashicorp/terraform-plugin-framework/resource"
+	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
+	"github.com/hashicorp/terraform-plugin-framework/tfsdk"
+	"github.com/hashicorp/terraform-plugin-framework/types"
+	"github.com/hashicorp/terraform-plugin-go/tfprotov6"
+	"github.com/hashicorp/terraform-plugin-go/tftypes"
+	"github.com/stretchr/testify/assert"
+	"github.com/stretchr/testify/require"
+	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
+)
+
+// withStateUpgraders temporarily registers upgrades for a component.
+func withStateUpgraders(t *testing.T, managerName string, upgrades []is.StateUpgrade) {
+	prev, had := stateUpgraders[managerName]
+	stateUpgraders[managerName] = upgrades
+	t.Cleanup(func() {
+		if had {
+			stateUpgraders[managerName] = prev
+		} else {
+			delete(stateUpgraders, managerName)
+		}
+	})
+}
+
+func runStateUpgrade(t *testing.T, r *Resource, fromVersion int64, priorJSON string) (*tfsdk.State, *resource.UpgradeStateResponse) {
+	ctx := context.Background()
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	schema := manager.TfState().Schema.(rschema.Schema)
+
+	upgrader, ok := r.UpgradeState(ctx)[fromVersion]
+	require.True(t, ok, "no upgrader for version %d", fromVersion)
+
+	resp := &resource.UpgradeStateResponse{State: tfsdk.State{Schema: schema}}
+	upgrader.StateUpgrader(ctx, resource.UpgradeStateRequest{
+		RawState: &tfprotov6.RawState{JSON: []byte(priorJSON)},
+	}, resp)
+	return &resp.State, resp
+}
+
+func TestStateUpgraders_RegistryIsValid(t *testing.T) {
+	for name, upgrades := range stateUpgraders {
+		_, err := is.StateSchemaVersion(upgrades)
+		require.NoError(t, err, "component %q", name)
+	}
+}
+
+func TestStateUpgrade_SchemaVersion(t *testing.T) {
+	withStateUpgraders(t, "test", []is.StateUpgrade{{FromVersion: 0}, {FromVersion: 1}})
+	r := buildTestResourceWithSchema(rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id": rschema.Int64Attribute{Computed: true},
+	}}, nil)
+	manager, err := r.ManagerWithSchemaOnly(context.Background())
+	require.NoError(t, err)
+	assert.Equal(t, int64(2), manager.TfState().Schema.(rschema.Schema).Version)
+	assert.Len(t, r.UpgradeState(context.Background()), 2)
+}
+
+func TestStateUpgrade_CustomResource(t *testing.T) {
+	withStateUpgraders(t, "test", []is.StateUpgrade{
+		{FromVersion: 0, Ops: []is.StateUpgradeOp{
+			is.RenameField{From: "type_", To: "type"},
+			is.DropField{Field: "legacy"},
+		}},
+		{FromVersion: 1, Ops: []is.StateUpgradeOp{
+			is.RetypeField{Field: "settings", Convert: is.ToJSONString},
+		}},
+	})
+	r := buildTestResourceWithSchema(rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id":       rschema.Int64Attribute{Computed: true},
+		"type":     rschema.StringAttribute{Optional: true},
+		"settings": rschema.StringAttribute{Optional: true},
+		"tags":     rschema.SetAttribute{ElementType: types.StringType, Optional: true},
+	}}, nil)
+
+	tests := []struct {
+		name        string
+		fromVersion int64
+		priorJSON   string
+		expectType  string
+		expectJSON  string
+	}{
+		{
+			name:        "from version 0",
+			fromVersion: 0,
+			priorJSON:   `{"id": 5, "type_": "local", "legacy": true, "settings": {"a": 1}, "tags": ["x", "x"]}`,
+			expectType:  "local",
+			expectJSON:  `{"a":1}`,
+		},
+		{
+			name:        "from version 1",
+			fromVersion: 1,
+			priorJSON:   `{"id": 5, "type": "ad", "settings": {"b": "c"}, "tags": null}`,
+			expectType:  "ad",
+			expectJSON:  `{"b":"c"}`,
+		},
+	}
+	for _, tt := range tests {
+		t.Run(tt.name, func(t *testing.T) {
+			state, resp := runStateUpgrade(t, r, tt.fromVersion, tt.priorJSON)
+			require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+			var typ, settings types.String
+			var id types.Int64
+			require.False(t, state.GetAttribute(context.Background(), path.Root("type"), &typ).HasError())
+			require.False(t, state.GetAttribute(context.Background(), path.Root("settings"), &settings).HasError())
+			require.False(t, state.GetAttribute(context.Background(), path.Root("id"), &id).HasError())
+			assert.Equal(t, tt.expectType, typ.ValueString())
+			assert.JSONEq(t, tt.expectJSON, settings.ValueString())
+			assert.Equal(t, int64(5), id.ValueInt64())
+		})
+	}
+}
+
+func TestStateUpgrade_GeneratedResource(t *testing.T) {
+	withStateUpgraders(t, "view_policy", []is.StateUpgrade{
+		{FromVersion: 0, Ops: []is.StateUpgradeOp{
+			is.RenameField{From: "policy_name", To: "name"},
+			is.RetypeField{Field: "tenant_id", Convert: is.ToInt64Value},
+		}},
+	})
+	var r *Resource
+	for _, f := range GetResourceFactories() {
+		if res := f().(*Resource); res.managerName == "view_policy" {
+			r = res
+		}
+	}
+	require.NotNil(t, r)
+
+	// Recorded state of an older release where the name attribute was different and tenant_id was a string.
+	state, resp := runStateUpgrade(t, r, 0, `{"id": 3, "policy_name": "default", "tenant_id": "1", "removed_attr": "x"}`)
+	require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+
+	var name types.String
+	var tenantID types.Int64
+	require.False(t, state.GetAttribute(context.Background(), path.Root("name"), &name).HasError())
+	require.False(t, state.GetAttribute(context.Background(), path.Root("tenant_id"), &tenantID).HasError())
+	assert.Equal(t, "default", name.ValueString())
+	assert.Equal(t, int64(1), tenantID.ValueInt64())
+}
+
+func TestStateUpgrade_IncompatibleValue(t *testing.T) {
+	withStateUpgraders(t, "test", []is.StateUpgrade{{FromVersion: 0}})
+	r := buildTestResourceWithSchema(rschema.Schema{Attributes: map[string]rschema.Attribute{
+		"id": rschema.Int64Attribute{Computed: true},
+	}}, nil)
+	_, resp := runStateUpgrade(t, r, 0, `{"id": "not-a-number"}`)
+	require.True(t, resp.Diagnostics.HasError())
+}
+
+// quotaStateBeforeCapacityUnits is the state of a quota recorded with the provider release preceding
+// human readable capacities: hard_limit and soft_limit were numbers of bytes.
+const quotaStateBeforeCapacityUnits = `{
+  "cluster": "vast-cluster",
+  "cluster_id": 1,
+  "create_dir": true,
+  "create_dir_mode": null,
+  "default_email": "storage@example.com",
+  "default_group_quota": null,
+  "default_user_quota": {
+    "grace_period": "01:00:00",
+    "hard_limit": 10737418240,
+    "hard_limit_inodes": null,
+    "quota_system_id": 0,
+    "soft_limit": 5368709120,
+    "soft_limit_inodes": null
+  },
+  "enable_alarms": true,
+  "enable_email_providers": false,
+  "grace_period": "02:00:00",
+  "group_quotas": null,
+  "guid": "4d6a2f2e-5b8e-4a37-9d62-3c1b1f0a7e21",
+  "hard_limit": 1099511627776,
+  "hard_limit_inodes": 1000000,
+  "id": 12,
+  "inherit_acl": false,
+  "internal": false,
+  "is_user_quota": false,
+  "last_user_quotas_update": null,
+  "name": "projects",
+  "num_blocked_users": 0,
+  "num_exceeded_users": 0,
+  "path": "/projects",
+  "percent_capacity": 3,
+  "percent_inodes": 0,
+  "pretty_grace_period": "2 hours",
+  "pretty_grace_period_expiration": null,
+  "pretty_state": "OK",
+  "soft_limit": 549755813888,
+  "soft_limit_inodes": null,
+  "state": "OK",
+  "sync_state": "SYNCHRONIZED",
+  "system_id": 3,
+  "tenant_id": 1,
+  "tenant_name": "default",
+  "time_to_block": null,
+  "title": "projects",
+  "url": "https://vms.example.com/api/quotas/12",
+  "used_capacity": 34359738368,
+  "used_capacity_tb": 0.031,
+  "used_effective_capacity": 30064771072,
+  "used_effective_capacity_tb": 0.027,
+  "used_inodes": 5120,
+  "used_limited_capacity": 34359738368,
+  "user_quotas": [
+    {
+      "entity": {
+        "email": null,
+        "identifier": "1001",
+        "identifier_type": "uid",
+        "is_group": false,
+        "name": "alice",
+        "vast_id": 7
+      },
+      "entity_identifier": "1001",
+      "grace_period": "01:00:00",
+      "guid": null,
+      "hard_limit": 107374182400,
+      "hard_limit_inodes": null,
+      "id": 4,
+      "identifier": "1001",
+      "identifier_type": "uid",
+      "is_accountable": true,
+      "name": "alice",
+      "path": "/projects",
+      "percent_capacity": 0,
+      "percent_inodes": 0,
+      "quota_system_id": 0,
+      "soft_limit": null,
+      "soft_limit_inodes": null,
+      "state": "OK",
+      "time_to_block": null,
+      "used_capacity": 1048576,
+      "used_inodes": 12
+    }
+  ]
+}`
+
+// upgradeRecordedState decodes prior state of the given version the way the framework does:
+// registered upgraders are used if the version is older than the schema, otherwise
+// the state is decoded with the current schema as is.
+func upgradeRecordedState(t *testing.T, r *Resource, fromVersion int64, priorJSON string) *tfsdk.State {
+	ctx := context.Background()
+	manager, err := r.ManagerWithSchemaOnly(ctx)
+	require.NoError(t, err)
+	schema := manager.TfState().Schema.(rschema.Schema)
+	if fromVersion < schema.Version {
+		state, resp := runStateUpgrade(t, r, fromVersion, priorJSON)
+		require.False(t, resp.Diagnostics.HasError(), "%v", resp.Diagnostics)
+		return state
+	}
+
+	raw, err := (&tfprotov6.RawState{JSON: []byte(priorJSON)}).UnmarshalWithOpts(
+		schema.Type().TerraformType(ctx),
+		tfprotov6.UnmarshalOpts{ValueFromJSONOpts: tftypes.ValueFromJSONOpts{IgnoreUndefinedAttributes: true}},
+	)
+	require.NoError(t, err, "prior state does not decode with the current schema: register a state upgrade")
+	return &tfsdk.State{Schema: schema, Raw: raw}
+}
+
+func TestStateUpgrade_QuotaStateBeforeCapacityUnits(t *testing.T) {
+	ctx := context.Background()
+	r := findResource(t, "quota")
+	state := upgradeRecordedState(t, r, 0, quotaStateBeforeCapacityUnits)
+
+	tfState := r.NewManager(*state).TfState()
+	capacityEquals := func(field, expected string) {
+		t.Helper()
+		value, ok := tfState.Get(field).(is.CapacityValue)
+		require.True(t, ok, "%s is %T", field, tfState.Get(field))
+		equal, diags := value.StringSemanticEquals(ctx, is.NewCapacityValue(is.NewCapacityType("B"), expected))
+		require.False(t, diags.HasError(), "%v", diags)
+		assert.True(t, equal, "%s: %s != %s", field, value.ValueString(), expected)
+	}
+	capacityEquals("hard_limit", "1TiB")
+	capacityEquals("soft_limit", "512GiB")
+	assert.Equal(t, "/projects", tfState.String("path"))
+	assert.Equal(t, int64(1000000), tfState.Int64("hard_limit_inodes"))
+
+	// Capacities of nested objects decode as well and are sent in bytes.
+	userQuotas := tfState.ToSlice("user_quotas")
+	require.Len(t, userQuotas, 1)
+	assert.Equal(t, int64(100<<30), userQuotas[0].(map[string]any)["hard_limit"])
+}
@@ -26,7 +26,7 @@ func (m *Tenant) NewResourceManager(raw map[string]attr.Value, schema any) Resou
 		&is.TFStateHints{
 			SchemaRef:             TenantSchemaRef,
 			DeleteOnlyParamFields: map[string]string{"force_delete": "force"},
-			PreserveOrderFields:   []string{"client_ip_ranges"},
+			CustomTypeMapping:     map[string]string{"client_ip_ranges": CustomTypeIPRangeList},
 			AdditionalSchemaAttributes: map[string]any{
 				"force_delete": rschema.BoolAttribute{
 					Optional: true,
@@ -34,6 +34,7 @@ func (m *Tenant) NewResourceManager(raw map[string]attr.Value, schema any) Resou
 						" or other removable remnants. Use with caution, as this will bypass standard cleanup checks.",
 				},
 			},
+			VolatileFields: []string{"sync_time"},
 		},
 	)}
 }
@@ -43,8 +44,8 @@ func (m *Tenant) NewDatasourceManager(raw map[string]attr.Value, schema any) Dat
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef:           TenantSchemaRef,
-			PreserveOrderFields: []string{"client_ip_ranges"},
+			SchemaRef:         TenantSchemaRef,
+			CustomTypeMapping: map[string]string{"client_ip_ranges": CustomTypeIPRangeList},
 		}),
 	}
 }
@@ -29,7 +29,8 @@ func (m *TenantClientMetrics) NewResourceManager(raw map[string]attr.Value, sche
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: TenantClientMetricsSchemaRef,
+			SchemaRef:      TenantClientMetricsSchemaRef,
+			IdentityFields: []string{"tenant_id"},
 			AdditionalSchemaAttributes: map[string]any{
 				"tenant_id": rschema.Int64Attribute{
 					Required:    true,
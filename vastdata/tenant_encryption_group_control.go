@@ -5,11 +5,13 @@ import (
 	"context"
 	"fmt"
 
+	"github.com/hashicorp/terraform-plugin-framework-validators/stringvalidator"
 	"github.com/hashicorp/terraform-plugin-framework/attr"
 	rschema "github.com/hashicorp/terraform-plugin-framework/resource/schema"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/int64planmodifier"
 	planmodifiers "github.com/hashicorp/terraform-plugin-framework/resource/schema/planmodifier"
 	"github.com/hashicorp/terraform-plugin-framework/resource/schema/stringplanmodifier"
+	"github.com/hashicorp/terraform-plugin-framework/schema/validator"
 	is "github.com/vast-data/terraform-provider-vastdata/vastdata/internalstate"
 )
 
@@ -38,6 +40,9 @@ func (m *TenantEncryptionGroupControl) NewResourceManager(raw map[string]attr.Va
 						PlanModifiers: []planmodifiers.String{
 							stringplanmodifier.RequiresReplace(),
 						},
+						Validators: []validator.String{
+							stringvalidator.OneOf("revoke", "deactivate", "reinstate", "rotate_key"),
+						},
 					},
 				},
 			},
@@ -53,10 +58,6 @@ func (m *TenantEncryptionGroupControl) API(rest *VMSRest) VastResourceAPIWithCon
 	return rest.Tenants
 }
 
-func (m *TenantEncryptionGroupControl) ValidateResourceConfig(context.Context) error {
-	return ValidateFieldIsOneOf(m.tfstate, "action", "revoke", "deactivate", "reinstate", "rotate_key")
-}
-
 func (m *TenantEncryptionGroupControl) CreateResource(ctx context.Context, rest *VMSRest) (DisplayableRecord, error) {
 	return m.performAction(ctx, rest)
 }
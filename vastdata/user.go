@@ -25,8 +25,12 @@ func (m *User) NewResourceManager(raw map[string]attr.Value, schema any) Resourc
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef:       UserSchemaRef,
-			SensitiveFields: []string{"password"},
+			// VMS users API has no password (users authenticate with S3 access keys, see vastdata_user_key),
+			// so there is no secret to make write-only.
+			SchemaRef: UserSchemaRef,
+			ForeignKeyFields: map[string]string{
+				"s3_policies_ids": "s3_policy",
+			},
 			AdditionalSchemaAttributes: map[string]any{
 				"s3_policies_ids": rschema.SetAttribute{
 					ElementType: types.Int64Type,
@@ -27,8 +27,10 @@ func (m *UserCopy) NewResourceManager(raw map[string]attr.Value, schema any) Res
 		raw,
 		schema,
 		&is.TFStateHints{
-			Importable: &notImportable,
-			SchemaRef:  UserCopySchemaRef,
+			Importable:     &notImportable,
+			SchemaRef:      UserCopySchemaRef,
+			IdentityFields: []string{"destination_provider_id", "tenant_id"},
+			ExactlyOneOf:   [][]string{{"tenant_id", "user_ids"}},
 		},
 	)}
 }
@@ -48,7 +50,7 @@ func (m *UserCopy) ReadResource(_ context.Context, _ *VMSRest) (DisplayableRecor
 func (m *UserCopy) CreateResource(ctx context.Context, rest *VMSRest) (DisplayableRecord, error) {
 	ts := m.tfstate
 
-	// Validate that either tenant_id or user_ids is provided, but not both
+	// ExactlyOneOf hint validates configuration, but values unknown at that time are checked only here.
 	hasTenantID := ts.IsKnownAndNotNull("tenant_id")
 	hasUserIDs := ts.IsKnownAndNotNull("user_ids")
 
@@ -29,7 +29,8 @@ func (m *UserTenantData) NewResourceManager(raw map[string]attr.Value, schema an
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef: UserTenantDataSchemaRef,
+			SchemaRef:      UserTenantDataSchemaRef,
+			IdentityFields: []string{"user_id"},
 			AdditionalSchemaAttributes: map[string]any{
 				"user_id": rschema.Int64Attribute{
 					Required:    true,
@@ -29,8 +29,9 @@ func (m *UserKey) NewResourceManager(raw map[string]attr.Value, schema any) Reso
 		raw,
 		schema,
 		&is.TFStateHints{
-			Importable: &notImportable,
-			SchemaRef:  UserKeySchemaRef,
+			Importable:     &notImportable,
+			SchemaRef:      UserKeySchemaRef,
+			IdentityFields: []string{"access_key", "user_id"},
 			AdditionalSchemaAttributes: map[string]any{
 				"user_id": rschema.Int64Attribute{
 					Optional:    true,
@@ -58,6 +59,9 @@ func (m *UserKey) NewResourceManager(raw map[string]attr.Value, schema any) Reso
 			},
 			SearchableFields: []string{"user_id", "username"},
 			SensitiveFields:  []string{"secret_key"},
+			ReferenceFields: map[string]is.ReferenceField{
+				"username": {Target: "user", Field: "user_id"},
+			},
 		},
 	)}
 }
@@ -70,15 +74,9 @@ func (m *UserKey) API(rest *VMSRest) VastResourceAPIWithContext {
 	return rest.UserKeys
 }
 
-func (m *UserKey) ReadResource(ctx context.Context, rest *VMSRest) (DisplayableRecord, error) {
-	ts := m.tfstate
-	if !ts.IsKnownAndNotNull("user_id") {
-		userRecord, err := rest.Users.GetWithContext(ctx, params{"name": ts.String("username")})
-		if err != nil {
-			return nil, err
-		}
-		ts.Set("user_id", userRecord.RecordID())
-	}
+func (m *UserKey) ReadResource(_ context.Context, _ *VMSRest) (DisplayableRecord, error) {
+	// Keys cannot be read back (secret is returned on creation only): keep state unchanged.
+	// user_id is resolved from username by the ReferenceFields hint.
 	return nil, nil
 }
 
@@ -96,8 +94,8 @@ func (m *UserKey) PrepareCreateResource(_ context.Context, _ *VMSRest) error {
 
 func (m *UserKey) CreateResource(ctx context.Context, rest *VMSRest) (DisplayableRecord, error) {
 	ts := m.tfstate
-	if _, err := m.ReadResource(ctx, rest); err != nil {
-		return nil, err
+	if !ts.IsKnownAndNotNull("user_id") {
+		return nil, errors.New("user_id or username must be specified")
 	}
 	userId := ts.Int64("user_id")
 	record, err := rest.UserKeys.CreateKeyWithContext(ctx, userId)
@@ -173,8 +171,8 @@ func (m *UserKey) DeleteResource(ctx context.Context, rest *VMSRest) error {
 	if accessKey == "" {
 		return errors.New("access_key must be specified for deletion")
 	}
-	if _, err := m.ReadResource(ctx, rest); err != nil {
-		return err
+	if !ts.IsKnownAndNotNull("user_id") {
+		return errors.New("user_id must be specified for deletion")
 	}
 	userId := ts.Int64("user_id")
 	_, err := rest.UserKeys.DeleteKeyWithContext(ctx, userId, accessKey)
@@ -29,6 +29,14 @@ func (m *View) NewResourceManager(raw map[string]attr.Value, schema any) Resourc
 			SchemaRef:            ViewSchemaRef,
 			DeleteOnlyBodyFields: map[string]string{"delete_dir": ""},
 			ImportFields:         []string{"path", "tenant_name"},
+			ForeignKeyFields: map[string]string{
+				"tenant_id":     "tenant",
+				"policy_id":     "view_policy",
+				"qos_policy_id": "qos_policy",
+			},
+			ReferenceFields: map[string]is.ReferenceField{
+				"tenant_name": {Target: "tenant", Field: "tenant_id"},
+			},
 			CommonValidatorsMapping: map[string]string{
 				"path":                     ValidatorPathStartsWithSlash,
 				"alias":                    ValidatorPathStartsWithSlash,
@@ -37,6 +45,14 @@ func (m *View) NewResourceManager(raw map[string]attr.Value, schema any) Resourc
 				"default_retention_period": ValidatorRetentionFormat,
 				"auto_commit":              ValidatorRetentionFormat,
 			},
+			CustomTypeMapping: map[string]string{
+				"path":                     CustomTypePath,
+				"alias":                    CustomTypePath,
+				"max_retention_period":     CustomTypeDuration,
+				"min_retention_period":     CustomTypeDuration,
+				"default_retention_period": CustomTypeDuration,
+				"auto_commit":              CustomTypeDuration,
+			},
 			AdditionalSchemaAttributes: map[string]any{
 				"delete_dir": rschema.BoolAttribute{
 					Optional: true,
@@ -45,6 +61,7 @@ func (m *View) NewResourceManager(raw map[string]attr.Value, schema any) Resourc
 						"For it to work properly, the Trash API must be enabled on the VAST cluster.",
 				},
 			},
+			VolatileFields: []string{"logical_capacity", "physical_capacity", "bulk_permission_update_progress", "sync_time"},
 		},
 	)}
 }
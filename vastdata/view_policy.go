@@ -26,6 +26,16 @@ func (m *ViewPolicy) NewResourceManager(raw map[string]attr.Value, schema any) R
 			SchemaRef:      ViewPolicySchemaRef,
 			ReadOnlyFields: []string{"serves_tenant"},
 			ImportFields:   []string{"name", "tenant_name"},
+			ForeignKeyFields: map[string]string{
+				"tenant_id": "tenant",
+			},
+			ReferenceFields: map[string]is.ReferenceField{
+				"tenant_name": {Target: "tenant", Field: "tenant_id"},
+			},
+			DeleteRetryOnConflict: &is.DeleteRetryOnConflict{
+				Dependents: []is.DependentReference{{Component: "view", Field: "policy_id"}},
+			},
+			VolatileFields: []string{"count_views", "sync_time"},
 		},
 	)}
 }
@@ -26,7 +26,8 @@ func (m *VipPool) NewResourceManager(raw map[string]attr.Value, schema any) Reso
 			SchemaRef:               VipPoolSchemaRef,
 			NotRequiredSchemaFields: []string{"subnet_cidr"},
 			ReadOnlyFields:          []string{"serves_tenant"},
-			PreserveOrderFields:     []string{"ip_ranges"},
+			CustomTypeMapping:       map[string]string{"ip_ranges": CustomTypeIPRangeList},
+			VolatileFields:          []string{"sync_time"},
 		},
 	)}
 }
@@ -36,8 +37,8 @@ func (m *VipPool) NewDatasourceManager(raw map[string]attr.Value, schema any) Da
 		raw,
 		schema,
 		&is.TFStateHints{
-			SchemaRef:           VipPoolSchemaRef,
-			PreserveOrderFields: []string{"ip_ranges"},
+			SchemaRef:         VipPoolSchemaRef,
+			CustomTypeMapping: map[string]string{"ip_ranges": CustomTypeIPRangeList},
 		}),
 	}
 }
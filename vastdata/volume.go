@@ -25,6 +25,7 @@ func (m *Volume) NewResourceManager(raw map[string]attr.Value, schema any) Resou
 		&is.TFStateHints{
 			SchemaRef:      VolumeSchemaRef,
 			ReadOnlyFields: []string{"tenant_id"},
+			CapacityFields: map[string]string{"size": "B"},
 		},
 	)}
 }